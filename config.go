@@ -1,5 +1,16 @@
 package logger
 
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wxlbd/awesome-log/internal"
+)
+
 // Option 定义日志选项函数类型
 type Option func(*Config)
 
@@ -7,7 +18,7 @@ type Option func(*Config)
 type Config struct {
 	// 日志级别: debug, info, warn, error, fatal
 	Level string `json:"level" yaml:"level"`
-	// 日志格式: json, console
+	// 控制台日志格式: json, console
 	Format string `json:"format" yaml:"format"`
 	// 是否输出到文件
 	WriteToFile bool `json:"writeToFile" yaml:"writeToFile"`
@@ -17,16 +28,39 @@ type Config struct {
 	FileConfig FileConfig `json:"fileConfig" yaml:"fileConfig"`
 	// 是否记录调用者信息
 	RecordCaller bool `json:"recordCaller" yaml:"recordCaller"`
-	// 时间格式
+	// 时间格式: Go 时间布局或 rfc3339, rfc3339nano, iso8601, epoch, epoch_millis, epoch_nanos
 	TimeFormat string `json:"timeFormat" yaml:"timeFormat"`
+	// 时区: local, utc，同时作用于日志时间和轮转文件的时间戳
+	TimeZone string `json:"timeZone" yaml:"timeZone"`
 	// 堆栈跟踪级别
 	StackLevel string `json:"stackLevel" yaml:"stackLevel"`
+
+	// 日志采样配置
+	Sampling SamplingConfig `json:"sampling" yaml:"sampling"`
+	// 日志速率限制配置
+	RateLimit RateLimitConfig `json:"rateLimit" yaml:"rateLimit"`
+	// 重复日志合并配置
+	Dedup DedupConfig `json:"dedup" yaml:"dedup"`
+
+	// 是否在 Init 时将 slog 的默认日志实例替换为全局日志实例
+	SlogDefault bool `json:"slogDefault" yaml:"slogDefault"`
+
+	// 上下文字段提取器
+	ContextExtractors []ContextExtractor `json:"-" yaml:"-"`
+	// 触发重新打开日志文件的信号
+	ReopenSignals []os.Signal `json:"-" yaml:"-"`
+
+	// 应用选项过程中产生的错误
+	err error
 }
 
 // FileConfig 文件输出配置
 type FileConfig struct {
 	// 日志文件路径
 	Filename string `json:"filename" yaml:"filename"`
+	// 日志文件路径模板，支持 {name}, {hostname}, {pid}, {date}, {level} 占位符，
+	// 设置后替代 Filename 生成各命名日志实例的文件路径
+	FilenamePattern string `json:"filenamePattern" yaml:"filenamePattern"`
 	// 单个日志文件最大大小（MB）
 	MaxSize int `json:"maxSize" yaml:"maxSize"`
 	// 最大保留天数
@@ -35,8 +69,128 @@ type FileConfig struct {
 	MaxBackups int `json:"maxBackups" yaml:"maxBackups"`
 	// 是否压缩
 	Compress bool `json:"compress" yaml:"compress"`
+	// 日志文件及其轮转文件的最大总大小（MB），为 0 时不限制
+	MaxTotalSize int `json:"maxTotalSize" yaml:"maxTotalSize"`
+	// 是否与同目录下的其他命名日志实例共同计算 MaxTotalSize
+	ShareTotalSize bool `json:"shareTotalSize" yaml:"shareTotalSize"`
+	// 日志目录所在卷的最小可用空间（MB），低于该值时只记录 Error 及以上级别的日志，为 0 时不检查
+	MinFreeSpace int `json:"minFreeSpace" yaml:"minFreeSpace"`
+	// 轮转策略: size, daily, hourly, size_and_time，默认按大小轮转
+	RotationPolicy string `json:"rotationPolicy" yaml:"rotationPolicy"`
 	// 日志格式: json, console
 	Format string `json:"format" yaml:"format"`
+	// 通过 WithName 创建的子日志实例是否与父实例共享日志文件
+	ShareParentFile bool `json:"shareParentFile" yaml:"shareParentFile"`
+	// 异步写入配置
+	Async AsyncConfig `json:"async" yaml:"async"`
+	// 缓冲写入配置
+	Buffer BufferConfig `json:"buffer" yaml:"buffer"`
+	// 按级别过滤的附加输出，与 Filename 同时写入
+	Outputs []OutputConfig `json:"outputs" yaml:"outputs"`
+}
+
+// OutputConfig 按级别过滤的附加文件输出配置
+//
+// 未设置的格式和轮转配置沿用 FileConfig 中的配置，异步和缓冲写入配置与 FileConfig 相同。
+type OutputConfig struct {
+	// 日志文件路径，包含占位符时作为路径模板，占位符与 FileConfig.FilenamePattern 相同
+	Filename string `json:"filename" yaml:"filename"`
+	// 写入该文件的最低日志级别
+	Level string `json:"level" yaml:"level"`
+	// 日志格式: json, console
+	Format string `json:"format" yaml:"format"`
+	// 单个日志文件最大大小（MB）
+	MaxSize int `json:"maxSize" yaml:"maxSize"`
+	// 最大保留天数
+	MaxAge int `json:"maxAge" yaml:"maxAge"`
+	// 最大保留文件数
+	MaxBackups int `json:"maxBackups" yaml:"maxBackups"`
+	// 是否压缩
+	Compress *bool `json:"compress" yaml:"compress"`
+	// 轮转策略: size, daily, hourly, size_and_time
+	RotationPolicy string `json:"rotationPolicy" yaml:"rotationPolicy"`
+}
+
+// fileConfig 将附加输出的配置合并到文件输出配置中
+func (o OutputConfig) fileConfig(fc FileConfig) FileConfig {
+	fc.Filename = o.Filename
+	fc.FilenamePattern = ""
+	fc.Outputs = nil
+	if o.Format != "" {
+		fc.Format = o.Format
+	}
+	if o.MaxSize != 0 {
+		fc.MaxSize = o.MaxSize
+	}
+	if o.MaxAge != 0 {
+		fc.MaxAge = o.MaxAge
+	}
+	if o.MaxBackups != 0 {
+		fc.MaxBackups = o.MaxBackups
+	}
+	if o.Compress != nil {
+		fc.Compress = *o.Compress
+	}
+	if o.RotationPolicy != "" {
+		fc.RotationPolicy = o.RotationPolicy
+	}
+	return fc
+}
+
+// validate 校验附加输出配置，field 为配置项名称前缀
+func (o OutputConfig) validate(field string) []error {
+	var errs []error
+	if o.Filename == "" {
+		errs = append(errs, &ConfigError{Field: field + ".filename", Value: o.Filename, Err: ErrEmptyValue})
+	} else {
+		for _, placeholder := range internal.UnknownPlaceholders(o.Filename) {
+			errs = append(errs, &ConfigError{Field: field + ".filename", Value: placeholder, Err: ErrUnknownPlaceholder})
+		}
+		if dir := filepath.Dir(o.Filename); !writableDir(dir) {
+			errs = append(errs, &ConfigError{Field: field + ".filename", Value: o.Filename, Err: ErrUnwritableDir})
+		}
+	}
+	if _, err := internal.ParseLevel(o.Level); err != nil {
+		errs = append(errs, &ConfigError{Field: field + ".level", Value: o.Level, Err: ErrUnknownLevel})
+	}
+	if o.Format != "" && !validFormat(o.Format) {
+		errs = append(errs, &ConfigError{Field: field + ".format", Value: o.Format, Err: ErrUnknownFormat})
+	}
+	if o.MaxSize < 0 {
+		errs = append(errs, &ConfigError{Field: field + ".maxSize", Value: o.MaxSize, Err: ErrNegativeValue})
+	}
+	if o.MaxAge < 0 {
+		errs = append(errs, &ConfigError{Field: field + ".maxAge", Value: o.MaxAge, Err: ErrNegativeValue})
+	}
+	if o.MaxBackups < 0 {
+		errs = append(errs, &ConfigError{Field: field + ".maxBackups", Value: o.MaxBackups, Err: ErrNegativeValue})
+	}
+	if !internal.RotationPolicies[o.RotationPolicy] {
+		errs = append(errs, &ConfigError{Field: field + ".rotationPolicy", Value: o.RotationPolicy, Err: ErrUnknownRotationPolicy})
+	}
+	return errs
+}
+
+// BufferConfig 缓冲写入配置
+type BufferConfig struct {
+	// 是否启用缓冲写入
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// 缓冲区大小（字节），为 0 时使用 256KB
+	Size int `json:"size" yaml:"size"`
+	// 刷新间隔，为 0 时使用 30 秒
+	FlushInterval time.Duration `json:"flushInterval" yaml:"flushInterval"`
+}
+
+// AsyncConfig 异步写入配置
+type AsyncConfig struct {
+	// 是否启用异步写入
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// 队列容量（条）
+	BufferSize int `json:"bufferSize" yaml:"bufferSize"`
+	// 刷新间隔，为 0 时队列空闲即写入
+	FlushInterval time.Duration `json:"flushInterval" yaml:"flushInterval"`
+	// 队列满时的处理策略: block, drop_newest, drop_oldest
+	Policy string `json:"policy" yaml:"policy"`
 }
 
 // DefaultConfig 返回默认配置
@@ -49,6 +203,7 @@ func DefaultConfig() *Config {
 		RecordCaller: true,
 		StackLevel:   "fatal",
 		TimeFormat:   "2006-01-02 15:04:05.000",
+		TimeZone:     "local",
 		FileConfig: FileConfig{
 			Filename:   "logs/app.log",
 			MaxSize:    100,
@@ -60,6 +215,195 @@ func DefaultConfig() *Config {
 	}
 }
 
+// useDefaults 修正无法通过校验的配置，目录不可写的错误保留
+//
+// 无法识别的级别、格式、时区和轮转策略替换为默认值，负数替换为 0，
+// 配置错误的采样、限流、去重、异步写入和附加输出不启用，
+// 包含未知占位符的文件名模板不使用。
+func (c *Config) useDefaults() {
+	defaults := DefaultConfig()
+	if _, err := internal.ParseLevel(c.Level); err != nil {
+		c.Level = defaults.Level
+	}
+	if _, err := internal.ParseLevel(c.StackLevel); err != nil {
+		c.StackLevel = defaults.StackLevel
+	}
+	if !validFormat(c.Format) {
+		c.Format = defaults.Format
+	}
+	if _, ok := internal.TimeZones[strings.ToLower(c.TimeZone)]; !ok {
+		c.TimeZone = defaults.TimeZone
+	}
+	if !validFormat(c.FileConfig.Format) {
+		c.FileConfig.Format = defaults.FileConfig.Format
+	}
+	if !internal.RotationPolicies[c.FileConfig.RotationPolicy] {
+		c.FileConfig.RotationPolicy = defaults.FileConfig.RotationPolicy
+	}
+
+	if c.Sampling.Enabled && len(c.Sampling.validate()) > 0 {
+		c.Sampling.Enabled = false
+	}
+	if c.RateLimit.Enabled && len(c.RateLimit.validate()) > 0 {
+		c.RateLimit.Enabled = false
+	}
+	if c.Dedup.Enabled && len(c.Dedup.validate()) > 0 {
+		c.Dedup.Enabled = false
+	}
+
+	fc := &c.FileConfig
+	fc.MaxSize = max(fc.MaxSize, 0)
+	fc.MaxAge = max(fc.MaxAge, 0)
+	fc.MaxBackups = max(fc.MaxBackups, 0)
+	fc.MaxTotalSize = max(fc.MaxTotalSize, 0)
+	fc.MinFreeSpace = max(fc.MinFreeSpace, 0)
+	if fc.Async.Enabled && (fc.Async.BufferSize <= 0 || fc.Async.FlushInterval < 0 || !internal.Policies[fc.Async.Policy]) {
+		fc.Async.Enabled = false
+	}
+	fc.Buffer.Size = max(fc.Buffer.Size, 0)
+	fc.Buffer.FlushInterval = max(fc.Buffer.FlushInterval, 0)
+	if len(internal.UnknownPlaceholders(fc.FilenamePattern)) > 0 {
+		fc.FilenamePattern = ""
+	}
+
+	// 复制附加输出，避免修改调用方传入的切片
+	var outputs []OutputConfig
+	for _, o := range fc.Outputs {
+		if o.Filename == "" || len(internal.UnknownPlaceholders(o.Filename)) > 0 {
+			continue
+		}
+		if _, err := internal.ParseLevel(o.Level); err != nil {
+			continue
+		}
+		if !validFormat(o.Format) {
+			o.Format = ""
+		}
+		o.MaxSize = max(o.MaxSize, 0)
+		o.MaxAge = max(o.MaxAge, 0)
+		o.MaxBackups = max(o.MaxBackups, 0)
+		if !internal.RotationPolicies[o.RotationPolicy] {
+			o.RotationPolicy = ""
+		}
+		outputs = append(outputs, o)
+	}
+	fc.Outputs = outputs
+}
+
+// Validate 校验配置，返回所有配置问题合并后的错误
+func (c *Config) Validate() error {
+	var errs []error
+
+	if _, err := internal.ParseLevel(c.Level); err != nil {
+		errs = append(errs, &ConfigError{Field: "level", Value: c.Level, Err: ErrUnknownLevel})
+	}
+	if _, err := internal.ParseLevel(c.StackLevel); err != nil {
+		errs = append(errs, &ConfigError{Field: "stackLevel", Value: c.StackLevel, Err: ErrUnknownLevel})
+	}
+	if !validFormat(c.Format) {
+		errs = append(errs, &ConfigError{Field: "format", Value: c.Format, Err: ErrUnknownFormat})
+	}
+	if _, ok := internal.TimeZones[strings.ToLower(c.TimeZone)]; !ok {
+		errs = append(errs, &ConfigError{Field: "timeZone", Value: c.TimeZone, Err: ErrUnknownTimeZone})
+	}
+
+	if c.Sampling.Enabled {
+		errs = append(errs, c.Sampling.validate()...)
+	}
+	if c.RateLimit.Enabled {
+		errs = append(errs, c.RateLimit.validate()...)
+	}
+	if c.Dedup.Enabled {
+		errs = append(errs, c.Dedup.validate()...)
+	}
+
+	if c.WriteToFile {
+		fc := c.FileConfig
+		if !validFormat(fc.Format) {
+			errs = append(errs, &ConfigError{Field: "fileConfig.format", Value: fc.Format, Err: ErrUnknownFormat})
+		}
+		if fc.MaxSize < 0 {
+			errs = append(errs, &ConfigError{Field: "fileConfig.maxSize", Value: fc.MaxSize, Err: ErrNegativeValue})
+		}
+		if fc.MaxAge < 0 {
+			errs = append(errs, &ConfigError{Field: "fileConfig.maxAge", Value: fc.MaxAge, Err: ErrNegativeValue})
+		}
+		if fc.MaxBackups < 0 {
+			errs = append(errs, &ConfigError{Field: "fileConfig.maxBackups", Value: fc.MaxBackups, Err: ErrNegativeValue})
+		}
+		if fc.MaxTotalSize < 0 {
+			errs = append(errs, &ConfigError{Field: "fileConfig.maxTotalSize", Value: fc.MaxTotalSize, Err: ErrNegativeValue})
+		}
+		if fc.MinFreeSpace < 0 {
+			errs = append(errs, &ConfigError{Field: "fileConfig.minFreeSpace", Value: fc.MinFreeSpace, Err: ErrNegativeValue})
+		}
+		if !internal.RotationPolicies[fc.RotationPolicy] {
+			errs = append(errs, &ConfigError{Field: "fileConfig.rotationPolicy", Value: fc.RotationPolicy, Err: ErrUnknownRotationPolicy})
+		}
+		if fc.Async.Enabled {
+			if fc.Async.BufferSize <= 0 {
+				errs = append(errs, &ConfigError{Field: "fileConfig.async.bufferSize", Value: fc.Async.BufferSize, Err: ErrNonPositiveValue})
+			}
+			if fc.Async.FlushInterval < 0 {
+				errs = append(errs, &ConfigError{Field: "fileConfig.async.flushInterval", Value: fc.Async.FlushInterval, Err: ErrNegativeValue})
+			}
+			if !internal.Policies[fc.Async.Policy] {
+				errs = append(errs, &ConfigError{Field: "fileConfig.async.policy", Value: fc.Async.Policy, Err: ErrUnknownPolicy})
+			}
+		}
+		if fc.Buffer.Enabled {
+			if fc.Buffer.Size < 0 {
+				errs = append(errs, &ConfigError{Field: "fileConfig.buffer.size", Value: fc.Buffer.Size, Err: ErrNegativeValue})
+			}
+			if fc.Buffer.FlushInterval < 0 {
+				errs = append(errs, &ConfigError{Field: "fileConfig.buffer.flushInterval", Value: fc.Buffer.FlushInterval, Err: ErrNegativeValue})
+			}
+		}
+		for i, output := range fc.Outputs {
+			errs = append(errs, output.validate(fmt.Sprintf("fileConfig.outputs[%d]", i))...)
+		}
+		if fc.FilenamePattern != "" {
+			for _, placeholder := range internal.UnknownPlaceholders(fc.FilenamePattern) {
+				errs = append(errs, &ConfigError{Field: "fileConfig.filenamePattern", Value: placeholder, Err: ErrUnknownPlaceholder})
+			}
+			if dir := filepath.Dir(fc.FilenamePattern); !writableDir(dir) {
+				errs = append(errs, &ConfigError{Field: "fileConfig.filenamePattern", Value: fc.FilenamePattern, Err: ErrUnwritableDir})
+			}
+		} else if dir := filepath.Dir(fc.Filename); !writableDir(dir) {
+			errs = append(errs, &ConfigError{Field: "fileConfig.filename", Value: fc.Filename, Err: ErrUnwritableDir})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validFormat 判断日志格式是否合法
+func validFormat(format string) bool {
+	_, ok := internal.EncoderMap[format]
+	return ok
+}
+
+// writableDir 判断目录是否可写，目录不存在时检查最近的已存在上级目录
+func writableDir(dir string) bool {
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return false
+			}
+			return internal.Writable(dir)
+		}
+		if !os.IsNotExist(err) {
+			return false
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
+
 // WithLevel 设置日志级别
 func WithLevel(level string) Option {
 	return func(c *Config) {
@@ -67,20 +411,27 @@ func WithLevel(level string) Option {
 	}
 }
 
-// WithFormat 设置日志格式
+// WithFormat 设置控制台输出格式，json 格式下自动禁用彩色输出
 func WithFormat(format string) Option {
 	return func(c *Config) {
 		c.Format = format
 	}
 }
 
-// WithTimeFormat 设置时间格式
+// WithTimeFormat 设置时间格式，支持 Go 时间布局和预定义格式名称
 func WithTimeFormat(format string) Option {
 	return func(c *Config) {
 		c.TimeFormat = format
 	}
 }
 
+// WithTimeZone 设置时区: local, utc
+func WithTimeZone(timeZone string) Option {
+	return func(c *Config) {
+		c.TimeZone = timeZone
+	}
+}
+
 // WithColor 设置是否启用彩色输出
 func WithColor(enable bool) Option {
 	return func(c *Config) {
@@ -106,14 +457,38 @@ func WithStackLevel(level string) Option {
 func WithFileRotation(filename string, maxSize, maxAge, maxBackups int, compress bool) Option {
 	return func(c *Config) {
 		c.WriteToFile = true
-		c.FileConfig = FileConfig{
-			Filename:   filename,
-			MaxSize:    maxSize,
-			MaxAge:     maxAge,
-			MaxBackups: maxBackups,
-			Compress:   compress,
-			Format:     "json",
-		}
+		c.FileConfig.Filename = filename
+		c.FileConfig.MaxSize = maxSize
+		c.FileConfig.MaxAge = maxAge
+		c.FileConfig.MaxBackups = maxBackups
+		c.FileConfig.Compress = compress
+		c.FileConfig.Format = "json"
+	}
+}
+
+// WithFilenamePattern 设置日志文件路径模板
+//
+// 支持的占位符: {name} 日志实例名称, {hostname} 主机名, {pid} 进程号,
+// {date} 创建文件时的日期, {level} 日志级别。例如 "logs/{name}/app.log"。
+// 名称中的路径分隔符等特殊字符会替换为下划线。设置模板即启用文件输出。
+func WithFilenamePattern(pattern string) Option {
+	return func(c *Config) {
+		c.WriteToFile = true
+		c.FileConfig.FilenamePattern = pattern
+	}
+}
+
+// WithLevelOutput 将 level 及以上级别的日志额外写入 filename
+//
+// 例如 WithLevelOutput("warn", "logs/app.error.log")。格式和轮转配置沿用文件输出的配置，
+// 需要单独设置时可通过 FileConfig.Outputs 配置。设置附加输出即启用文件输出。
+func WithLevelOutput(level, filename string) Option {
+	return func(c *Config) {
+		c.WriteToFile = true
+		c.FileConfig.Outputs = append(c.FileConfig.Outputs, OutputConfig{
+			Filename: filename,
+			Level:    level,
+		})
 	}
 }
 
@@ -124,6 +499,104 @@ func WithFileFormat(format string) Option {
 	}
 }
 
+// WithContextExtractor 注册上下文字段提取器
+func WithContextExtractor(extractors ...ContextExtractor) Option {
+	return func(c *Config) {
+		c.ContextExtractors = append(c.ContextExtractors, extractors...)
+	}
+}
+
+// WithSlogDefault 设置是否在 Init 时通过 slog.SetDefault 接管 slog 的默认输出
+func WithSlogDefault(enable bool) Option {
+	return func(c *Config) {
+		c.SlogDefault = enable
+	}
+}
+
+// WithShareParentFile 设置通过 WithName 创建的子日志实例是否与父实例共享日志文件
+func WithShareParentFile(enable bool) Option {
+	return func(c *Config) {
+		c.FileConfig.ShareParentFile = enable
+	}
+}
+
+// WithMaxTotalSize 限制日志文件及其轮转文件的总大小（MB），超出时删除最旧的轮转文件
+//
+// shared 为 true 时，同目录下与 Filename 同名前缀的所有命名日志实例文件共同计算总大小。
+func WithMaxTotalSize(maxTotalSize int, shared bool) Option {
+	return func(c *Config) {
+		c.FileConfig.MaxTotalSize = maxTotalSize
+		c.FileConfig.ShareTotalSize = shared
+	}
+}
+
+// WithMinFreeSpace 设置日志目录所在卷的最小可用空间（MB），低于该值时只记录 Error 及以上级别的日志
+func WithMinFreeSpace(minFreeSpace int) Option {
+	return func(c *Config) {
+		c.FileConfig.MinFreeSpace = minFreeSpace
+	}
+}
+
+// 文件轮转策略
+const (
+	// RotationSize 按文件大小轮转
+	RotationSize = internal.RotationSize
+	// RotationDaily 每天轮转，文件名形如 app-2006-01-02.log
+	RotationDaily = internal.RotationDaily
+	// RotationHourly 每小时轮转，文件名形如 app-2006-01-02-15.log
+	RotationHourly = internal.RotationHourly
+	// RotationSizeAndTime 每天轮转，且单个文件超过 MaxSize 时轮转
+	RotationSizeAndTime = internal.RotationSizeAndTime
+)
+
+// WithRotationPolicy 设置文件轮转策略
+//
+// 按时间轮转时以 TimeZone 配置的时区计算周期边界，MaxAge、MaxBackups 和 Compress 同样生效。
+func WithRotationPolicy(policy string) Option {
+	return func(c *Config) {
+		c.FileConfig.RotationPolicy = policy
+	}
+}
+
+// 异步写入队列满时的处理策略
+const (
+	// PolicyBlock 阻塞等待队列空闲
+	PolicyBlock = internal.PolicyBlock
+	// PolicyDropNewest 丢弃当前写入的日志
+	PolicyDropNewest = internal.PolicyDropNewest
+	// PolicyDropOldest 丢弃队列中最早的日志
+	PolicyDropOldest = internal.PolicyDropOldest
+)
+
+// WithAsync 启用文件异步写入
+//
+// bufferSize 为队列容量（条），flushInterval 为批量写入的刷新间隔，
+// policy 为队列满时的处理策略: PolicyBlock, PolicyDropNewest, PolicyDropOldest。
+func WithAsync(bufferSize int, flushInterval time.Duration, policy string) Option {
+	return func(c *Config) {
+		c.FileConfig.Async = AsyncConfig{
+			Enabled:       true,
+			BufferSize:    bufferSize,
+			FlushInterval: flushInterval,
+			Policy:        policy,
+		}
+	}
+}
+
+// WithBuffer 启用文件缓冲写入
+//
+// size 为缓冲区大小（字节），flushInterval 为定时刷新间隔，
+// Error 及以上级别的日志和 Sync 调用会立即刷新缓冲区。
+func WithBuffer(size int, flushInterval time.Duration) Option {
+	return func(c *Config) {
+		c.FileConfig.Buffer = BufferConfig{
+			Enabled:       true,
+			Size:          size,
+			FlushInterval: flushInterval,
+		}
+	}
+}
+
 // WithFullConfig 使用完整配置
 func WithFullConfig(config *Config) Option {
 	return func(c *Config) {
@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// ContextExtractor 从上下文中提取日志字段
+type ContextExtractor func(ctx context.Context) []zap.Field
+
+// contextKey 上下文字段的键
+type contextKey struct{}
+
+// WithContext 将字段保存到上下文中，使用该上下文记录日志时自动附加这些字段
+func WithContext(ctx context.Context, fields ...zap.Field) context.Context {
+	existing, _ := ctx.Value(contextKey{}).([]zap.Field)
+	merged := make([]zap.Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, contextKey{}, merged)
+}
+
+// FromContext 返回绑定了上下文字段的全局日志实例
+func FromContext(ctx context.Context) *Logger {
+	return globalLogger.Load().WithContext(ctx)
+}
+
+// WithContext 返回绑定了上下文字段的子日志实例
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	fields := l.contextFields(ctx, nil)
+	if len(fields) == 0 {
+		return l
+	}
+	return l.with(fields)
+}
+
+// contextFields 合并上下文中保存的字段、提取器提取的字段和调用时传入的字段
+func (l *Logger) contextFields(ctx context.Context, fields []zap.Field) []zap.Field {
+	if ctx == nil {
+		return fields
+	}
+
+	var ctxFields []zap.Field
+	if stored, ok := ctx.Value(contextKey{}).([]zap.Field); ok {
+		ctxFields = append(ctxFields, stored...)
+	}
+	for _, extract := range l.load().config.ContextExtractors {
+		ctxFields = append(ctxFields, extract(ctx)...)
+	}
+	if len(ctxFields) == 0 {
+		return fields
+	}
+	return append(ctxFields, fields...)
+}
+
+// DebugCtx 输出Debug级别日志，附加上下文字段
+func (l *Logger) DebugCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	l.load().zap.Debug(msg, l.contextFields(ctx, fields)...)
+}
+
+// InfoCtx 输出Info级别日志，附加上下文字段
+func (l *Logger) InfoCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	l.load().zap.Info(msg, l.contextFields(ctx, fields)...)
+}
+
+// WarnCtx 输出Warn级别日志，附加上下文字段
+func (l *Logger) WarnCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	l.load().zap.Warn(msg, l.contextFields(ctx, fields)...)
+}
+
+// ErrorCtx 输出Error级别日志，附加上下文字段
+func (l *Logger) ErrorCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	l.load().zap.Error(msg, l.contextFields(ctx, fields)...)
+}
+
+// FatalCtx 输出Fatal级别日志，附加上下文字段
+func (l *Logger) FatalCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	l.load().zap.Fatal(msg, l.contextFields(ctx, fields)...)
+}
+
+// 以下是全局函数，使用全局logger实例
+
+// DebugCtx 输出Debug级别日志，附加上下文字段
+func DebugCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	globalLogger.Load().DebugCtx(ctx, msg, fields...)
+}
+
+// InfoCtx 输出Info级别日志，附加上下文字段
+func InfoCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	globalLogger.Load().InfoCtx(ctx, msg, fields...)
+}
+
+// WarnCtx 输出Warn级别日志，附加上下文字段
+func WarnCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	globalLogger.Load().WarnCtx(ctx, msg, fields...)
+}
+
+// ErrorCtx 输出Error级别日志，附加上下文字段
+func ErrorCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	globalLogger.Load().ErrorCtx(ctx, msg, fields...)
+}
+
+// FatalCtx 输出Fatal级别日志，附加上下文字段
+func FatalCtx(ctx context.Context, msg string, fields ...zap.Field) {
+	globalLogger.Load().FatalCtx(ctx, msg, fields...)
+}
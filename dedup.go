@@ -0,0 +1,35 @@
+package logger
+
+import "time"
+
+// DedupConfig 重复日志合并配置
+type DedupConfig struct {
+	// 是否启用重复日志合并
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// 合并窗口
+	Window time.Duration `json:"window" yaml:"window"`
+	// 参与比较的字段名，级别、消息和这些字段的值均相同的日志视为重复日志
+	Fields []string `json:"fields" yaml:"fields"`
+}
+
+// WithDedup 合并时间窗口内的重复日志
+//
+// 窗口内第一条日志立即输出，之后的重复日志被抑制，窗口结束或调用 Sync 时
+// 输出一条带 repeat_count 字段的汇总日志。fields 指定参与比较的字段名。
+func WithDedup(window time.Duration, fields ...string) Option {
+	return func(c *Config) {
+		c.Dedup = DedupConfig{
+			Enabled: true,
+			Window:  window,
+			Fields:  fields,
+		}
+	}
+}
+
+// validate 校验重复日志合并配置
+func (dc DedupConfig) validate() []error {
+	if dc.Window <= 0 {
+		return []error{&ConfigError{Field: "dedup.window", Value: dc.Window, Err: ErrNonPositiveValue}}
+	}
+	return nil
+}
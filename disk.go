@@ -0,0 +1,25 @@
+package logger
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// diskCheckInterval 检查日志目录可用空间的间隔
+const diskCheckInterval = 10 * time.Second
+
+// diskNotice 返回可用空间跨过下限时输出提示的函数
+func diskNotice(zapLogger *zap.Logger, minFreeSpace int) func(low bool, free uint64) {
+	// 提示日志不记录调用者信息
+	zapLogger = zapLogger.WithOptions(zap.WithCaller(false))
+	return func(low bool, free uint64) {
+		freeMB := free / 1024 / 1024
+		if low {
+			zapLogger.Error(fmt.Sprintf("磁盘可用空间 %d MB 低于 %d MB，只记录 Error 及以上级别的日志", freeMB, minFreeSpace), zap.Uint64("freeMB", freeMB))
+			return
+		}
+		zapLogger.Info(fmt.Sprintf("磁盘可用空间已恢复到 %d MB，恢复正常日志记录", freeMB), zap.Uint64("freeMB", freeMB))
+	}
+}
@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnknownLevel 未知的日志级别
+	ErrUnknownLevel = errors.New("未知的日志级别")
+	// ErrUnknownFormat 未知的日志格式
+	ErrUnknownFormat = errors.New("未知的日志格式")
+	// ErrUnknownTimeZone 未知的时区
+	ErrUnknownTimeZone = errors.New("未知的时区")
+	// ErrNegativeValue 数值不能为负数
+	ErrNegativeValue = errors.New("数值不能为负数")
+	// ErrNonPositiveValue 数值必须为正数
+	ErrNonPositiveValue = errors.New("数值必须为正数")
+	// ErrEmptyValue 配置项不能为空
+	ErrEmptyValue = errors.New("配置项不能为空")
+	// ErrUnknownPolicy 未知的队列满处理策略
+	ErrUnknownPolicy = errors.New("未知的队列满处理策略")
+	// ErrUnknownRotationPolicy 未知的文件轮转策略
+	ErrUnknownRotationPolicy = errors.New("未知的文件轮转策略")
+	// ErrUnknownPlaceholder 未知的文件名占位符
+	ErrUnknownPlaceholder = errors.New("未知的文件名占位符")
+	// ErrUnwritableDir 日志目录不可写
+	ErrUnwritableDir = errors.New("日志目录不可写")
+	// ErrWriterConflict 日志文件已被其他日志实例以不同的写入配置打开
+	ErrWriterConflict = errors.New("日志文件已被其他日志实例以不同的写入配置打开")
+)
+
+// ConfigError 配置项错误，可通过 errors.Is 判断具体的错误类型
+type ConfigError struct {
+	// 配置项名称，如 fileConfig.maxSize
+	Field string
+	// 配置项的值
+	Value interface{}
+	// 错误原因
+	Err error
+}
+
+// Error 实现 error 接口
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("%s: %v: %v", e.Field, e.Err, e.Value)
+}
+
+// Unwrap 返回错误原因
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// levelEntry 日志实例及其级别
+type levelEntry struct {
+	Name  string `json:"name"`
+	Level string `json:"level"`
+}
+
+// levelRequest 级别修改请求，Name 为空指针时修改所有日志实例
+type levelRequest struct {
+	Name  *string `json:"name"`
+	Level string  `json:"level"`
+}
+
+// LevelHandler 返回用于查看和修改日志级别的 HTTP 处理器
+//
+// GET 列出所有日志实例及其当前级别；
+// PUT/POST 接收 {"name": "user-service", "level": "debug"}，
+// 省略 name 时修改所有日志实例的级别。
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, listLevels())
+		case http.MethodPut, http.MethodPost:
+			var req levelRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("解析请求失败: %w", err))
+				return
+			}
+
+			if req.Name == nil {
+				if err := SetGlobalLevel(req.Level); err != nil {
+					writeError(w, http.StatusBadRequest, err)
+					return
+				}
+				writeJSON(w, http.StatusOK, listLevels())
+				return
+			}
+
+			logger, exists := loggers()[*req.Name]
+			if !exists {
+				writeError(w, http.StatusNotFound, fmt.Errorf("日志实例不存在: %q", *req.Name))
+				return
+			}
+			if err := logger.SetLevel(req.Level); err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, levelEntry{Name: logger.Name(), Level: logger.Level()})
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("不支持的请求方法: %s", r.Method))
+		}
+	})
+}
+
+// listLevels 按名称排序列出所有日志实例的级别
+func listLevels() []levelEntry {
+	all := loggers()
+	entries := make([]levelEntry, 0, len(all))
+	for name, logger := range all {
+		entries = append(entries, levelEntry{Name: name, Level: logger.Level()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// writeJSON 输出 JSON 响应
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError 输出 JSON 格式的错误响应
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package internal
+
+import "os"
+
+// Writable 判断目录是否可写，当前平台仅检查目录的写权限位
+func Writable(dir string) bool {
+	info, err := os.Stat(dir)
+	return err == nil && info.Mode().Perm()&0200 != 0
+}
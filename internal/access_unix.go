@@ -0,0 +1,10 @@
+//go:build unix
+
+package internal
+
+import "golang.org/x/sys/unix"
+
+// Writable 判断当前进程是否可以在目录中创建文件，不会修改目录内容
+func Writable(dir string) bool {
+	return unix.Access(dir, unix.W_OK) == nil
+}
@@ -0,0 +1,183 @@
+package internal
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 异步写入队列已满时的处理策略
+const (
+	// PolicyBlock 阻塞等待队列空闲
+	PolicyBlock = "block"
+	// PolicyDropNewest 丢弃当前写入的日志
+	PolicyDropNewest = "drop_newest"
+	// PolicyDropOldest 丢弃队列中最早的日志
+	PolicyDropOldest = "drop_oldest"
+)
+
+// Policies 支持的队列满处理策略
+var Policies = map[string]bool{
+	PolicyBlock:      true,
+	PolicyDropNewest: true,
+	PolicyDropOldest: true,
+}
+
+// maxBatchSize 批量写入的最大字节数，超过后立即写入
+const maxBatchSize = 256 * 1024
+
+// asyncEntry 异步队列中的条目，flushed 不为空时表示刷新请求
+type asyncEntry struct {
+	data    []byte
+	flushed chan struct{}
+}
+
+// AsyncWriter 基于有界队列的异步写入器
+//
+// 后台协程批量写入日志，按刷新间隔或批量大小写入底层输出。
+type AsyncWriter struct {
+	out           io.Writer
+	queue         chan asyncEntry
+	policy        string
+	flushInterval time.Duration
+	dropped       atomic.Uint64
+
+	// mu 保护 closed，Close 与写入并发时避免向已关闭的队列发送
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+}
+
+// NewAsyncWriter 创建异步写入器，flushInterval 为 0 时队列空闲即写入
+func NewAsyncWriter(out io.Writer, bufferSize int, flushInterval time.Duration, policy string) *AsyncWriter {
+	w := &AsyncWriter{
+		out:           out,
+		queue:         make(chan asyncEntry, bufferSize),
+		policy:        policy,
+		flushInterval: flushInterval,
+		done:          make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Write 实现 io.Writer 接口，队列满时按策略阻塞或丢弃日志
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	// 关闭后直接写入底层输出
+	if w.closed {
+		return w.out.Write(p)
+	}
+
+	// zap 会复用编码缓冲区，需要复制数据
+	entry := asyncEntry{data: append([]byte(nil), p...)}
+	switch w.policy {
+	case PolicyDropNewest:
+		select {
+		case w.queue <- entry:
+		default:
+			w.dropped.Add(1)
+		}
+	case PolicyDropOldest:
+		for {
+			select {
+			case w.queue <- entry:
+				return len(p), nil
+			default:
+			}
+			select {
+			case old := <-w.queue:
+				if old.flushed != nil {
+					// 刷新请求不能丢弃，放回队列后重试
+					w.queue <- old
+					continue
+				}
+				w.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		w.queue <- entry
+	}
+	return len(p), nil
+}
+
+// Sync 等待队列中已有的日志全部写入底层输出
+func (w *AsyncWriter) Sync() error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return nil
+	}
+	flushed := make(chan struct{})
+	w.queue <- asyncEntry{flushed: flushed}
+	<-flushed
+	return nil
+}
+
+// Close 写入队列中剩余的日志并停止后台协程
+func (w *AsyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.queue)
+	w.mu.Unlock()
+
+	<-w.done
+	return nil
+}
+
+// Dropped 返回队列满时丢弃的日志条数
+func (w *AsyncWriter) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// run 后台批量写入日志
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+
+	var ticker <-chan time.Time
+	if w.flushInterval > 0 {
+		t := time.NewTicker(w.flushInterval)
+		defer t.Stop()
+		ticker = t.C
+	}
+
+	var buf bytes.Buffer
+	flush := func() {
+		if buf.Len() > 0 {
+			_, _ = w.out.Write(buf.Bytes())
+			buf.Reset()
+		}
+	}
+
+	for {
+		select {
+		case entry, ok := <-w.queue:
+			if !ok {
+				flush()
+				return
+			}
+			if entry.flushed != nil {
+				flush()
+				close(entry.flushed)
+				continue
+			}
+
+			buf.Write(entry.data)
+			if buf.Len() >= maxBatchSize || (w.flushInterval == 0 && len(w.queue) == 0) {
+				flush()
+			}
+		case <-ticker:
+			flush()
+		}
+	}
+}
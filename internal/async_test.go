@@ -0,0 +1,134 @@
+package internal
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gatedWriter 在 gate 关闭前阻塞写入，用于让后台协程停在写入过程中
+type gatedWriter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	entered chan struct{}
+	gate    chan struct{}
+	once    sync.Once
+}
+
+func newGatedWriter() *gatedWriter {
+	return &gatedWriter{
+		entered: make(chan struct{}),
+		gate:    make(chan struct{}),
+	}
+}
+
+func (w *gatedWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.entered) })
+	<-w.gate
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *gatedWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestAsyncWriterPolicies(t *testing.T) {
+	tests := []struct {
+		policy  string
+		want    string
+		dropped uint64
+	}{
+		{policy: PolicyDropNewest, want: "abc", dropped: 2},
+		{policy: PolicyDropOldest, want: "ade", dropped: 2},
+		{policy: PolicyBlock, want: "abcde", dropped: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			out := newGatedWriter()
+			w := NewAsyncWriter(out, 2, 0, tt.policy)
+
+			// 第一条日志被后台协程取出后阻塞在写入中，队列容量为 2
+			_, _ = w.Write([]byte("a"))
+			<-out.entered
+
+			written := make(chan struct{})
+			go func() {
+				defer close(written)
+				for _, s := range []string{"b", "c", "d", "e"} {
+					_, _ = w.Write([]byte(s))
+				}
+			}()
+
+			if tt.policy != PolicyBlock {
+				<-written
+			}
+			close(out.gate)
+			<-written
+
+			if err := w.Sync(); err != nil {
+				t.Fatalf("Sync 失败: %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("输出 = %q, 期望 %q", got, tt.want)
+			}
+			if got := w.Dropped(); got != tt.dropped {
+				t.Errorf("Dropped() = %d, 期望 %d", got, tt.dropped)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close 失败: %v", err)
+			}
+		})
+	}
+}
+
+func TestAsyncWriterSyncDrainsQueue(t *testing.T) {
+	out := newGatedWriter()
+	close(out.gate)
+
+	// 刷新间隔足够长，只有 Sync 会触发写入
+	w := NewAsyncWriter(out, 16, time.Hour, PolicyBlock)
+	defer w.Close()
+
+	for _, s := range []string{"a", "b", "c"} {
+		_, _ = w.Write([]byte(s))
+	}
+	if got := out.String(); got != "" {
+		t.Fatalf("Sync 之前输出 = %q, 期望为空", got)
+	}
+
+	if err := w.Sync(); err != nil {
+		t.Fatalf("Sync 失败: %v", err)
+	}
+	if got := out.String(); got != "abc" {
+		t.Errorf("Sync 之后输出 = %q, 期望 %q", got, "abc")
+	}
+}
+
+func TestAsyncWriterCloseDrainsQueue(t *testing.T) {
+	out := newGatedWriter()
+	close(out.gate)
+
+	w := NewAsyncWriter(out, 16, time.Hour, PolicyBlock)
+	for _, s := range []string{"a", "b", "c"} {
+		_, _ = w.Write([]byte(s))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close 失败: %v", err)
+	}
+	if got := out.String(); got != "abc" {
+		t.Errorf("Close 之后输出 = %q, 期望 %q", got, "abc")
+	}
+
+	// 关闭后直接写入底层输出
+	_, _ = w.Write([]byte("d"))
+	if got := out.String(); got != "abcd" {
+		t.Errorf("关闭后写入输出 = %q, 期望 %q", got, "abcd")
+	}
+}
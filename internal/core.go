@@ -0,0 +1,38 @@
+package internal
+
+import "go.uber.org/zap/zapcore"
+
+// flushCore 写入指定级别及以上的日志后立即刷新输出
+type flushCore struct {
+	zapcore.Core
+	level zapcore.Level
+}
+
+// NewFlushCore 包装 core，写入 level 及以上级别的日志后调用 Sync
+func NewFlushCore(core zapcore.Core, level zapcore.Level) zapcore.Core {
+	return &flushCore{Core: core, level: level}
+}
+
+// With 实现 zapcore.Core 接口
+func (c *flushCore) With(fields []zapcore.Field) zapcore.Core {
+	return &flushCore{Core: c.Core.With(fields), level: c.level}
+}
+
+// Check 实现 zapcore.Core 接口
+func (c *flushCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write 实现 zapcore.Core 接口
+func (c *flushCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if err := c.Core.Write(ent, fields); err != nil {
+		return err
+	}
+	if ent.Level >= c.level {
+		return c.Core.Sync()
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Deduper 在时间窗口内合并重复日志
+//
+// 窗口内第一条日志立即输出，之后的重复日志被抑制，
+// 窗口结束时输出一条带 repeat_count 字段的汇总日志。
+type Deduper struct {
+	mu      sync.Mutex
+	window  time.Duration
+	keys    []string
+	pending map[string]*dedupWindow
+}
+
+// dedupWindow 单条日志的去重窗口
+type dedupWindow struct {
+	core    zapcore.Core
+	entry   zapcore.Entry
+	fields  []zapcore.Field
+	start   time.Time
+	repeats int
+}
+
+// dedupCore 按 Deduper 合并重复日志的 core
+type dedupCore struct {
+	zapcore.Core
+	deduper *Deduper
+	context []zapcore.Field
+}
+
+// NewDedupCore 包装 core，level、message 和 keys 指定字段的值均相同的日志视为重复日志
+func NewDedupCore(core zapcore.Core, window time.Duration, keys []string) (zapcore.Core, *Deduper) {
+	deduper := &Deduper{
+		window:  window,
+		keys:    keys,
+		pending: make(map[string]*dedupWindow),
+	}
+	return &dedupCore{Core: core, deduper: deduper}, deduper
+}
+
+// With 实现 zapcore.Core 接口，子 core 共享同一个 Deduper
+func (c *dedupCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	context = append(context, c.context...)
+	context = append(context, fields...)
+	return &dedupCore{Core: c.Core.With(fields), deduper: c.deduper, context: context}
+}
+
+// Check 实现 zapcore.Core 接口
+func (c *dedupCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write 实现 zapcore.Core 接口
+func (c *dedupCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	d := c.deduper
+	key := d.key(ent, c.context, fields)
+
+	d.mu.Lock()
+	window, exists := d.pending[key]
+	if exists && ent.Time.Sub(window.start) < d.window {
+		window.repeats++
+		d.mu.Unlock()
+		return nil
+	}
+	d.pending[key] = &dedupWindow{
+		core:   c.Core,
+		entry:  ent,
+		fields: append([]zapcore.Field(nil), fields...),
+		start:  ent.Time,
+	}
+	d.mu.Unlock()
+
+	// 上一个窗口已结束，先输出其汇总日志
+	if exists {
+		window.emit()
+	}
+	if checked := c.Core.Check(ent, nil); checked != nil {
+		checked.Write(fields...)
+	}
+	return nil
+}
+
+// Sync 实现 zapcore.Core 接口，输出所有窗口的汇总日志
+func (c *dedupCore) Sync() error {
+	c.deduper.Flush(true)
+	return c.Core.Sync()
+}
+
+// Flush 输出已结束窗口的汇总日志，all 为 true 时输出所有窗口
+func (d *Deduper) Flush(all bool) {
+	now := time.Now()
+	var windows []*dedupWindow
+
+	d.mu.Lock()
+	for key, window := range d.pending {
+		if all || now.Sub(window.start) >= d.window {
+			delete(d.pending, key)
+			windows = append(windows, window)
+		}
+	}
+	d.mu.Unlock()
+
+	for _, window := range windows {
+		window.emit()
+	}
+}
+
+// Run 定期输出已结束窗口的汇总日志，直到 done 被关闭
+func (d *Deduper) Run(done <-chan struct{}) {
+	ticker := time.NewTicker(d.window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			d.Flush(false)
+		}
+	}
+}
+
+// key 根据级别、消息和指定字段的值生成去重键
+func (d *Deduper) key(ent zapcore.Entry, context, fields []zapcore.Field) string {
+	var b strings.Builder
+	b.WriteString(ent.Level.String())
+	b.WriteByte(0)
+	b.WriteString(ent.Message)
+	if len(d.keys) == 0 {
+		return b.String()
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range context {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+	for _, key := range d.keys {
+		b.WriteByte(0)
+		fmt.Fprint(&b, enc.Fields[key])
+	}
+	return b.String()
+}
+
+// emit 输出带 repeat_count 字段的汇总日志，窗口内没有重复日志时不输出
+func (w *dedupWindow) emit() {
+	if w.repeats == 0 {
+		return
+	}
+
+	ent := w.entry
+	ent.Time = time.Now()
+	fields := append(w.fields, zap.Int("repeat_count", w.repeats))
+	if checked := w.core.Check(ent, nil); checked != nil {
+		checked.Write(fields...)
+	}
+}
@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// DiskGuard 定期检查日志目录所在卷的可用空间，空间不足时只允许 Error 及以上级别的日志写入
+type DiskGuard struct {
+	dir     string
+	minFree uint64
+	low     atomic.Bool
+}
+
+// NewDiskGuard 创建磁盘空间保护，minFree 为可用空间下限（字节）
+func NewDiskGuard(dir string, minFree uint64) *DiskGuard {
+	return &DiskGuard{dir: dir, minFree: minFree}
+}
+
+// Low 返回可用空间是否低于下限
+func (g *DiskGuard) Low() bool {
+	return g.low.Load()
+}
+
+// Run 立即检查一次可用空间，之后每隔 interval 检查一次，直到 done 关闭
+//
+// 可用空间跨过下限时调用 onChange。当前平台不支持查询可用空间时直接返回。
+func (g *DiskGuard) Run(interval time.Duration, done <-chan struct{}, onChange func(low bool, free uint64)) {
+	if !g.check(onChange) {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			g.check(onChange)
+		}
+	}
+}
+
+// check 检查可用空间，返回当前平台是否支持查询
+func (g *DiskGuard) check(onChange func(low bool, free uint64)) bool {
+	free, err := FreeSpace(g.dir)
+	if err != nil {
+		// 目录暂时不可访问时保持原状态
+		return !errors.Is(err, errors.ErrUnsupported)
+	}
+	low := free < g.minFree
+	if g.low.Swap(low) != low && onChange != nil {
+		onChange(low, free)
+	}
+	return true
+}
+
+// diskGuardCore 可用空间不足时丢弃 Error 以下级别的日志
+type diskGuardCore struct {
+	zapcore.Core
+	guard *DiskGuard
+}
+
+// NewDiskGuardCore 包装 core，guard 报告空间不足时只写入 Error 及以上级别的日志
+func NewDiskGuardCore(core zapcore.Core, guard *DiskGuard) zapcore.Core {
+	return &diskGuardCore{Core: core, guard: guard}
+}
+
+// Enabled 实现 zapcore.LevelEnabler 接口
+func (c *diskGuardCore) Enabled(level zapcore.Level) bool {
+	if level < zapcore.ErrorLevel && c.guard.Low() {
+		return false
+	}
+	return c.Core.Enabled(level)
+}
+
+// With 实现 zapcore.Core 接口
+func (c *diskGuardCore) With(fields []zapcore.Field) zapcore.Core {
+	return &diskGuardCore{Core: c.Core.With(fields), guard: c.guard}
+}
+
+// Check 实现 zapcore.Core 接口
+func (c *diskGuardCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd
+
+package internal
+
+import "errors"
+
+// FreeSpace 返回目录所在卷的可用空间（字节），当前平台不支持时返回 errors.ErrUnsupported
+func FreeSpace(dir string) (uint64, error) {
+	return 0, errors.ErrUnsupported
+}
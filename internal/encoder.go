@@ -5,22 +5,21 @@ import (
 	"path/filepath"
 	"time"
 
-	"github.com/fatih/color"
 	"go.uber.org/zap/zapcore"
 )
 
-// CustomTimeEncoder 自定义时间编码器
-func CustomTimeEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
-	enc.AppendString(color.New(color.FgWhite, color.Bold).Sprintf(
-		"%d-%02d-%02d %02d:%02d:%02d.%03d",
-		t.Year(),
-		t.Month(),
-		t.Day(),
-		t.Hour(),
-		t.Minute(),
-		t.Second(),
-		t.Nanosecond()/1e6,
-	))
+// EncoderMap 日志格式与编码器构造函数映射
+var EncoderMap = map[string]func(zapcore.EncoderConfig) zapcore.Encoder{
+	"json":    zapcore.NewJSONEncoder,
+	"console": zapcore.NewConsoleEncoder,
+}
+
+// NewEncoder 根据日志格式创建编码器，未知格式使用控制台编码器
+func NewEncoder(format string, config zapcore.EncoderConfig) zapcore.Encoder {
+	if newEncoder, ok := EncoderMap[format]; ok {
+		return newEncoder(config)
+	}
+	return zapcore.NewConsoleEncoder(config)
 }
 
 // CustomCallerEncoder 自定义调用者编码器
@@ -38,7 +37,7 @@ func CustomCallerEncoder(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayE
 }
 
 // GetConsoleEncoder 获取控制台编码器配置
-func GetConsoleEncoder(enableColor bool, timeFormat string) zapcore.EncoderConfig {
+func GetConsoleEncoder(enableColor bool, timeFormat string, loc *time.Location) zapcore.EncoderConfig {
 	encoderConfig := zapcore.EncoderConfig{
 		TimeKey:        "time",
 		LevelKey:       "level",
@@ -48,7 +47,7 @@ func GetConsoleEncoder(enableColor bool, timeFormat string) zapcore.EncoderConfi
 		MessageKey:     "msg",
 		StacktraceKey:  "stacktrace",
 		LineEnding:     zapcore.DefaultLineEnding,
-		EncodeTime:     CustomTimeEncoder,
+		EncodeTime:     GetConsoleTimeEncoder(enableColor, timeFormat, loc),
 		EncodeDuration: zapcore.SecondsDurationEncoder,
 		EncodeCaller:   CustomCallerEncoder,
 	}
@@ -63,7 +62,7 @@ func GetConsoleEncoder(enableColor bool, timeFormat string) zapcore.EncoderConfi
 }
 
 // GetFileEncoder 获取文件编码器配置
-func GetFileEncoder(timeFormat string) zapcore.EncoderConfig {
+func GetFileEncoder(timeFormat string, loc *time.Location) zapcore.EncoderConfig {
 	return zapcore.EncoderConfig{
 		TimeKey:        "time",
 		LevelKey:       "level",
@@ -74,7 +73,7 @@ func GetFileEncoder(timeFormat string) zapcore.EncoderConfig {
 		StacktraceKey:  "stacktrace",
 		LineEnding:     zapcore.DefaultLineEnding,
 		EncodeLevel:    GetPlainLevelEncoder(),
-		EncodeTime:     zapcore.TimeEncoderOfLayout(timeFormat),
+		EncodeTime:     GetTimeEncoder(timeFormat, loc),
 		EncodeDuration: zapcore.SecondsDurationEncoder,
 		EncodeCaller:   zapcore.ShortCallerEncoder,
 	}
@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilenamePlaceholders 日志文件名模板支持的占位符
+var FilenamePlaceholders = map[string]bool{
+	"{name}":     true,
+	"{hostname}": true,
+	"{pid}":      true,
+	"{date}":     true,
+	"{level}":    true,
+}
+
+// placeholderPattern 匹配模板中的占位符
+var placeholderPattern = regexp.MustCompile(`\{[^{}]*\}`)
+
+// unsafeChars 匹配文件名中不安全的字符
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// UnknownPlaceholders 返回模板中不支持的占位符
+func UnknownPlaceholders(pattern string) []string {
+	var unknown []string
+	for _, placeholder := range placeholderPattern.FindAllString(pattern, -1) {
+		if !FilenamePlaceholders[placeholder] {
+			unknown = append(unknown, placeholder)
+		}
+	}
+	return unknown
+}
+
+// ExpandFilename 替换模板中的占位符，生成日志文件路径
+//
+// name 和 hostname 会先经过 SanitizeFilename 处理，{date} 为 loc 时区的当前日期。
+func ExpandFilename(pattern, name, level string, loc *time.Location) string {
+	hostname, _ := os.Hostname()
+	replacer := strings.NewReplacer(
+		"{name}", SanitizeFilename(name),
+		"{hostname}", SanitizeFilename(hostname),
+		"{pid}", strconv.Itoa(os.Getpid()),
+		"{date}", time.Now().In(loc).Format(dailyLayout),
+		"{level}", level,
+	)
+	return replacer.Replace(pattern)
+}
+
+// SanitizeFilename 将字符串转换为安全的文件名片段
+//
+// 路径分隔符和其他特殊字符替换为下划线，并去掉开头的点，
+// 避免生成隐藏文件或跳出日志目录。
+func SanitizeFilename(s string) string {
+	s = unsafeChars.ReplaceAllString(s, "_")
+	return strings.TrimLeft(s, ".")
+}
@@ -33,6 +33,14 @@ func GetZapLevel(level string) zapcore.Level {
 	return zapcore.InfoLevel
 }
 
+// ParseLevel 解析日志级别，未知级别返回错误
+func ParseLevel(level string) (zapcore.Level, error) {
+	if zapLevel, ok := LevelMap[level]; ok {
+		return zapLevel, nil
+	}
+	return zapcore.InfoLevel, fmt.Errorf("未知的日志级别: %q", level)
+}
+
 // GetColorLevelEncoder 获取带颜色的级别编码器
 func GetColorLevelEncoder() zapcore.LevelEncoder {
 	return func(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RateLimiter 令牌桶限流器
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	// 是否处于限流状态，用于只输出一次限流提示
+	throttled bool
+}
+
+// NewRateLimiter 创建每秒生成 rate 个令牌、容量为 burst 的令牌桶
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Allow 获取一个令牌，返回是否允许输出，以及是否刚进入限流状态
+func (r *RateLimiter) Allow() (allowed, throttleStarted bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.tokens += now.Sub(r.last).Seconds() * r.rate
+	if r.tokens > r.burst {
+		r.tokens = r.burst
+	}
+	r.last = now
+
+	if r.tokens >= 1 {
+		r.tokens--
+		r.throttled = false
+		return true, false
+	}
+	if r.throttled {
+		return false, false
+	}
+	r.throttled = true
+	return false, true
+}
+
+// rateLimitCore 按令牌桶限制日志输出速率的 core
+type rateLimitCore struct {
+	zapcore.Core
+	limiter   *RateLimiter
+	onLimited func(zapcore.Entry)
+}
+
+// NewRateLimitCore 包装 core，超过速率限制的日志被丢弃并调用 onLimited，
+// 进入限流状态时输出一条限流提示
+func NewRateLimitCore(core zapcore.Core, limiter *RateLimiter, onLimited func(zapcore.Entry)) zapcore.Core {
+	return &rateLimitCore{Core: core, limiter: limiter, onLimited: onLimited}
+}
+
+// With 实现 zapcore.Core 接口，子 core 共享同一个限流器
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitCore{Core: c.Core.With(fields), limiter: c.limiter, onLimited: c.onLimited}
+}
+
+// Check 实现 zapcore.Core 接口
+func (c *rateLimitCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	allowed, throttleStarted := c.limiter.Allow()
+	if allowed {
+		return c.Core.Check(ent, ce)
+	}
+
+	c.onLimited(ent)
+	if throttleStarted {
+		notice := zapcore.Entry{
+			LoggerName: ent.LoggerName,
+			Time:       ent.Time,
+			Level:      zapcore.WarnLevel,
+			Message:    "日志输出速率超过限制，后续日志将被丢弃",
+		}
+		if checked := c.Core.Check(notice, nil); checked != nil {
+			checked.Write(zap.Float64("rate", c.limiter.rate), zap.Float64("burst", c.limiter.burst))
+		}
+	}
+	return ce
+}
@@ -0,0 +1,71 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// rotatedSuffix 匹配 lumberjack 和 TimeRotator 在文件名中插入的时间和序号后缀
+//
+// lumberjack: app-2006-01-02T15-04-05.000.log
+// TimeRotator: app-2006-01-02.log, app-2006-01-02-15.log, app-2006-01-02.1.log
+var rotatedSuffix = regexp.MustCompile(`-(\d{4}-\d{2}-\d{2}T\d{2}-\d{2}-\d{2}\.\d{3}|\d{4}-\d{2}-\d{2}(-\d{2})?(\.\d+)?)$`)
+
+// LogFileBase 返回日志文件名去掉压缩后缀、扩展名和轮转后缀后的基础名称
+//
+// 例如 app-2006-01-02T15-04-05.000.log.gz 的基础名称为 app。
+// 扩展名不是 ext 时返回 false。
+func LogFileBase(name, ext string) (string, bool) {
+	name = strings.TrimSuffix(name, ".gz")
+	if !strings.HasSuffix(name, ext) {
+		return "", false
+	}
+	name = strings.TrimSuffix(name, ext)
+	return rotatedSuffix.ReplaceAllString(name, ""), true
+}
+
+// TrimTotalSize 删除目录中最旧的日志文件，直到匹配文件的总大小不超过 limit（字节）
+//
+// match 判断文件名是否参与统计，active 中的文件正在写入，计入总大小但不会被删除。
+func TrimTotalSize(dir string, match func(name string) bool, active map[string]bool, limit int64) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	var backups []backupFile
+	for _, entry := range entries {
+		if entry.IsDir() || !match(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		total += info.Size()
+
+		path := filepath.Join(dir, entry.Name())
+		if !active[path] {
+			backups = append(backups, backupFile{path: path, modTime: info.ModTime(), size: info.Size()})
+		}
+	}
+
+	// 按修改时间从旧到新删除
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].modTime.Before(backups[j].modTime)
+	})
+	for _, backup := range backups {
+		if total <= limit {
+			break
+		}
+		if err := os.Remove(backup.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		total -= backup.size
+	}
+	return nil
+}
@@ -0,0 +1,355 @@
+package internal
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 日志文件轮转策略
+const (
+	// RotationSize 按文件大小轮转
+	RotationSize = "size"
+	// RotationDaily 每天轮转
+	RotationDaily = "daily"
+	// RotationHourly 每小时轮转
+	RotationHourly = "hourly"
+	// RotationSizeAndTime 每天轮转，且单个文件超过大小限制时轮转
+	RotationSizeAndTime = "size_and_time"
+)
+
+// RotationPolicies 支持的轮转策略，空字符串等同于按大小轮转
+var RotationPolicies = map[string]bool{
+	"":                  true,
+	RotationSize:        true,
+	RotationDaily:       true,
+	RotationHourly:      true,
+	RotationSizeAndTime: true,
+}
+
+// 轮转周期对应的文件名时间格式
+const (
+	dailyLayout  = "2006-01-02"
+	hourlyLayout = "2006-01-02-15"
+)
+
+// TimeRotator 按时间周期轮转的日志文件写入器
+//
+// 当前周期的日志写入 app-2006-01-02.log（每小时轮转时为 app-2006-01-02-15.log），
+// 周期内超过大小限制时将当前文件重命名为 app-2006-01-02.1.log 并重新打开。
+// 过期的文件按 MaxAge 和 MaxBackups 清理，启用压缩时旧文件压缩为 .gz。
+type TimeRotator struct {
+	// 日志文件路径，实际文件名在扩展名前插入时间
+	Filename string
+	// 轮转周期: daily, hourly
+	Period string
+	// 单个文件最大大小（MB），为 0 时不按大小轮转
+	MaxSize int
+	// 最大保留天数，为 0 时不按时间清理
+	MaxAge int
+	// 最大保留文件数，为 0 时不按数量清理
+	MaxBackups int
+	// 是否压缩旧文件
+	Compress bool
+	// 计算轮转周期使用的时区
+	Location *time.Location
+
+	mu     sync.Mutex
+	file   *os.File
+	stamp  string
+	size   int64
+	millMu sync.Mutex
+	// 正在运行的清理协程
+	milling sync.WaitGroup
+	// 是否已经打开过文件，首次打开时清理旧文件
+	opened bool
+	// 返回当前时间，为空时使用 time.Now，便于测试
+	now func() time.Time
+}
+
+// Write 实现 io.Writer 接口，跨越周期边界时切换到新文件
+func (r *TimeRotator) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stamp := r.stampOf(r.currentTime())
+	if r.file == nil || stamp != r.stamp {
+		if r.file != nil {
+			_ = r.file.Close()
+			r.file = nil
+			r.startMill()
+		}
+		if err := r.open(stamp); err != nil {
+			return 0, err
+		}
+	}
+
+	if maxSize := int64(r.MaxSize) * 1024 * 1024; maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Rotate 立即轮转当前文件
+func (r *TimeRotator) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		if err := r.open(r.stampOf(r.currentTime())); err != nil {
+			return err
+		}
+	}
+	return r.rotate()
+}
+
+// Active 返回当前正在写入的文件路径，尚未打开文件时返回空字符串
+func (r *TimeRotator) Active() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return ""
+	}
+	return r.file.Name()
+}
+
+// Close 实现 io.Closer 接口
+func (r *TimeRotator) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// open 打开指定周期的日志文件
+func (r *TimeRotator) open(stamp string) error {
+	name := r.nameOf(stamp, 0)
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return fmt.Errorf("创建日志目录失败: %w", err)
+	}
+
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("打开日志文件失败: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("读取日志文件信息失败: %w", err)
+	}
+
+	r.file = file
+	r.stamp = stamp
+	r.size = info.Size()
+
+	// 与 lumberjack 一致，首次打开时按保留配置清理旧文件
+	if !r.opened {
+		r.opened = true
+		r.startMill()
+	}
+	return nil
+}
+
+// rotate 将当前文件重命名为带序号的文件并重新打开
+func (r *TimeRotator) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	r.file = nil
+
+	name := r.nameOf(r.stamp, 0)
+	for index := 1; ; index++ {
+		backup := r.nameOf(r.stamp, index)
+		if exists(backup) || exists(backup+".gz") {
+			continue
+		}
+		if err := os.Rename(name, backup); err != nil {
+			return fmt.Errorf("轮转日志文件失败: %w", err)
+		}
+		break
+	}
+
+	r.startMill()
+	return r.open(r.stamp)
+}
+
+// currentTime 返回当前时间
+func (r *TimeRotator) currentTime() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+// stampOf 返回时间所在周期的文件名时间
+func (r *TimeRotator) stampOf(t time.Time) string {
+	loc := r.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	if r.Period == RotationHourly {
+		return t.In(loc).Format(hourlyLayout)
+	}
+	return t.In(loc).Format(dailyLayout)
+}
+
+// nameOf 返回指定周期和序号的文件名，序号为 0 时为当前周期的文件
+func (r *TimeRotator) nameOf(stamp string, index int) string {
+	ext := filepath.Ext(r.Filename)
+	prefix := r.Filename[:len(r.Filename)-len(ext)]
+	if index == 0 {
+		return fmt.Sprintf("%s-%s%s", prefix, stamp, ext)
+	}
+	return fmt.Sprintf("%s-%s.%d%s", prefix, stamp, index, ext)
+}
+
+// startMill 在后台清理旧文件
+func (r *TimeRotator) startMill() {
+	r.milling.Add(1)
+	go func() {
+		defer r.milling.Done()
+		r.mill()
+	}()
+}
+
+// mill 清理过期的旧文件并压缩剩余的旧文件
+func (r *TimeRotator) mill() {
+	r.millMu.Lock()
+	defer r.millMu.Unlock()
+
+	r.mu.Lock()
+	active := ""
+	if r.file != nil {
+		active = r.file.Name()
+	}
+	r.mu.Unlock()
+
+	backups, err := r.backups(active)
+	if err != nil {
+		return
+	}
+
+	// 按修改时间从新到旧排序
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].modTime.After(backups[j].modTime)
+	})
+
+	cutoff := r.currentTime().Add(-time.Duration(r.MaxAge) * 24 * time.Hour)
+	var remaining []backupFile
+	for i, backup := range backups {
+		if (r.MaxBackups > 0 && i >= r.MaxBackups) || (r.MaxAge > 0 && backup.modTime.Before(cutoff)) {
+			_ = os.Remove(backup.path)
+			continue
+		}
+		remaining = append(remaining, backup)
+	}
+
+	if !r.Compress {
+		return
+	}
+	for _, backup := range remaining {
+		if !strings.HasSuffix(backup.path, ".gz") {
+			_ = compressFile(backup.path)
+		}
+	}
+}
+
+// backupFile 旧日志文件
+type backupFile struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+// backups 列出除当前文件外的所有旧文件
+func (r *TimeRotator) backups(active string) ([]backupFile, error) {
+	ext := filepath.Ext(r.Filename)
+	base := filepath.Base(r.Filename[:len(r.Filename)-len(ext)])
+	dir := filepath.Dir(r.Filename)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backupFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.TrimSuffix(name, ".gz") == base+ext {
+			continue
+		}
+		// 只处理带时间后缀的轮转文件，忽略 app-server.log 等无关文件
+		if fileBase, ok := LogFileBase(name, ext); !ok || fileBase != base {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if path == active {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backupFile{path: path, modTime: info.ModTime()})
+	}
+	return backups, nil
+}
+
+// compressFile 将文件压缩为 .gz 并删除原文件
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode())
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	// 保留原文件的修改时间，保证按时间清理的结果一致
+	_ = os.Chtimes(path+".gz", info.ModTime(), info.ModTime())
+	return os.Remove(path)
+}
+
+// exists 判断文件是否存在
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,250 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock 可手动调整的时钟
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// newTestRotator 创建写入临时目录的 TimeRotator，测试结束时关闭并等待清理协程退出
+func newTestRotator(t *testing.T, r *TimeRotator, clock *fakeClock) *TimeRotator {
+	t.Helper()
+	r.Filename = filepath.Join(t.TempDir(), "app.log")
+	r.Location = time.UTC
+	r.now = clock.Now
+	t.Cleanup(func() {
+		_ = r.Close()
+		r.milling.Wait()
+	})
+	return r
+}
+
+// listFiles 返回目录中按名称排序的文件名
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("读取目录失败: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	slices.Sort(names)
+	return names
+}
+
+// readFile 读取文件内容
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("读取文件失败: %v", err)
+	}
+	return string(data)
+}
+
+func TestTimeRotatorPeriodBoundary(t *testing.T) {
+	tests := []struct {
+		period string
+		before time.Time
+		after  time.Time
+		files  []string
+	}{
+		{
+			period: RotationDaily,
+			before: time.Date(2026, 10, 16, 23, 59, 59, 0, time.UTC),
+			after:  time.Date(2026, 10, 17, 0, 0, 1, 0, time.UTC),
+			files:  []string{"app-2026-10-16.log", "app-2026-10-17.log"},
+		},
+		{
+			period: RotationHourly,
+			before: time.Date(2026, 10, 16, 14, 59, 59, 0, time.UTC),
+			after:  time.Date(2026, 10, 16, 15, 0, 1, 0, time.UTC),
+			files:  []string{"app-2026-10-16-14.log", "app-2026-10-16-15.log"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			clock := &fakeClock{now: tt.before}
+			r := newTestRotator(t, &TimeRotator{Period: tt.period}, clock)
+			dir := filepath.Dir(r.Filename)
+
+			if _, err := r.Write([]byte("before\n")); err != nil {
+				t.Fatalf("写入失败: %v", err)
+			}
+			clock.Set(tt.after)
+			if _, err := r.Write([]byte("after\n")); err != nil {
+				t.Fatalf("写入失败: %v", err)
+			}
+
+			if got := listFiles(t, dir); !slices.Equal(got, tt.files) {
+				t.Fatalf("文件 = %v, 期望 %v", got, tt.files)
+			}
+			if got := readFile(t, filepath.Join(dir, tt.files[0])); got != "before\n" {
+				t.Errorf("%s 内容 = %q", tt.files[0], got)
+			}
+			if got := readFile(t, filepath.Join(dir, tt.files[1])); got != "after\n" {
+				t.Errorf("%s 内容 = %q", tt.files[1], got)
+			}
+		})
+	}
+}
+
+func TestTimeRotatorSizeIndex(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	r := newTestRotator(t, &TimeRotator{Period: RotationSizeAndTime, MaxSize: 1}, clock)
+	dir := filepath.Dir(r.Filename)
+
+	// 每次写入 600KB，第二次写入起超过 1MB，依次轮转为 .1、.2
+	chunk := []byte(strings.Repeat("a", 600*1024))
+	for i := 0; i < 3; i++ {
+		if _, err := r.Write(chunk); err != nil {
+			t.Fatalf("写入失败: %v", err)
+		}
+	}
+
+	want := []string{"app-2026-10-16.1.log", "app-2026-10-16.2.log", "app-2026-10-16.log"}
+	if got := listFiles(t, dir); !slices.Equal(got, want) {
+		t.Fatalf("文件 = %v, 期望 %v", got, want)
+	}
+}
+
+func TestTimeRotatorRotateClosedFile(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	r := newTestRotator(t, &TimeRotator{Period: RotationDaily}, clock)
+	dir := filepath.Dir(r.Filename)
+
+	if _, err := r.Write([]byte("a\n")); err != nil {
+		t.Fatalf("写入失败: %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("关闭失败: %v", err)
+	}
+	// 文件关闭后调用 Rotate 也会轮转，与 lumberjack 一致
+	if err := r.Rotate(); err != nil {
+		t.Fatalf("轮转失败: %v", err)
+	}
+
+	want := []string{"app-2026-10-16.1.log", "app-2026-10-16.log"}
+	if got := listFiles(t, dir); !slices.Equal(got, want) {
+		t.Fatalf("文件 = %v, 期望 %v", got, want)
+	}
+	if got := readFile(t, filepath.Join(dir, "app-2026-10-16.1.log")); got != "a\n" {
+		t.Errorf("轮转文件内容 = %q", got)
+	}
+}
+
+func TestTimeRotatorMaxBackups(t *testing.T) {
+	tests := []struct {
+		name     string
+		compress bool
+		want     []string
+	}{
+		{
+			name: "plain",
+			want: []string{"app-2026-10-16.2.log", "app-2026-10-16.3.log", "app-2026-10-16.log", "app-server.log"},
+		},
+		{
+			name:     "compress",
+			compress: true,
+			want:     []string{"app-2026-10-16.2.log.gz", "app-2026-10-16.3.log.gz", "app-2026-10-16.log", "app-server.log"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+			r := newTestRotator(t, &TimeRotator{Period: RotationDaily, MaxBackups: 2, Compress: tt.compress}, clock)
+			dir := filepath.Dir(r.Filename)
+
+			// 与日志文件同前缀的无关文件不能被清理
+			unrelated := filepath.Join(dir, "app-server.log")
+			if err := os.WriteFile(unrelated, []byte("other\n"), 0644); err != nil {
+				t.Fatalf("写入文件失败: %v", err)
+			}
+
+			// 修改时间早于实际当前时间，保证后台清理在修改时间之前运行时顺序不变
+			base := time.Now().Add(-24 * time.Hour)
+			for i := 1; i <= 3; i++ {
+				if _, err := r.Write([]byte("entry\n")); err != nil {
+					t.Fatalf("写入失败: %v", err)
+				}
+				if err := r.Rotate(); err != nil {
+					t.Fatalf("轮转失败: %v", err)
+				}
+				r.milling.Wait()
+
+				// 轮转文件的修改时间决定清理顺序，序号越大越新，启用压缩时文件可能已压缩
+				backup := filepath.Join(dir, "app-2026-10-16."+strconv.Itoa(i)+".log")
+				if tt.compress {
+					backup += ".gz"
+				}
+				modTime := base.Add(time.Duration(i) * time.Hour)
+				if err := os.Chtimes(backup, modTime, modTime); err != nil && !os.IsNotExist(err) {
+					t.Fatalf("修改文件时间失败: %v", err)
+				}
+			}
+			r.mill()
+
+			if got := listFiles(t, dir); !slices.Equal(got, tt.want) {
+				t.Fatalf("文件 = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeRotatorMaxAgeOnOpen(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	r := newTestRotator(t, &TimeRotator{Period: RotationDaily, MaxAge: 1}, clock)
+	dir := filepath.Dir(r.Filename)
+
+	// 启动前遗留的过期文件在首次打开时清理
+	stale := filepath.Join(dir, "app-2026-10-10.log")
+	recent := filepath.Join(dir, "app-2026-10-15.log")
+	for path, modTime := range map[string]time.Time{
+		stale:  time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC),
+		recent: time.Date(2026, 10, 15, 23, 0, 0, 0, time.UTC),
+	} {
+		if err := os.WriteFile(path, []byte("old\n"), 0644); err != nil {
+			t.Fatalf("写入文件失败: %v", err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("修改文件时间失败: %v", err)
+		}
+	}
+
+	if _, err := r.Write([]byte("new\n")); err != nil {
+		t.Fatalf("写入失败: %v", err)
+	}
+	r.milling.Wait()
+
+	want := []string{"app-2026-10-15.log", "app-2026-10-16.log"}
+	if got := listFiles(t, dir); !slices.Equal(got, want) {
+		t.Fatalf("文件 = %v, 期望 %v", got, want)
+	}
+}
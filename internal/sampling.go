@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingRule 采样规则：每个周期内每条消息先输出 First 条，之后每 Thereafter 条输出一条
+type SamplingRule struct {
+	First      int
+	Thereafter int
+}
+
+// NewSampler 按级别对 core 采样，rules 中没有的级别不采样
+func NewSampler(core zapcore.Core, tick time.Duration, rules map[zapcore.Level]SamplingRule, hook func(zapcore.Entry, zapcore.SamplingDecision)) zapcore.Core {
+	cores := []zapcore.Core{
+		&levelCore{Core: core, enabled: func(level zapcore.Level) bool {
+			_, sampled := rules[level]
+			return !sampled
+		}},
+	}
+	for level, rule := range rules {
+		level := level
+		sampled := &levelCore{Core: core, enabled: func(l zapcore.Level) bool {
+			return l == level
+		}}
+		cores = append(cores, zapcore.NewSamplerWithOptions(sampled, tick, rule.First, rule.Thereafter, zapcore.SamplerHook(hook)))
+	}
+	return zapcore.NewTee(cores...)
+}
+
+// levelCore 仅处理指定级别日志的 core
+type levelCore struct {
+	zapcore.Core
+	enabled func(zapcore.Level) bool
+}
+
+// Enabled 实现 zapcore.LevelEnabler 接口
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.enabled(level) && c.Core.Enabled(level)
+}
+
+// With 实现 zapcore.Core 接口
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), enabled: c.enabled}
+}
+
+// Check 实现 zapcore.Core 接口
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"go.uber.org/zap/zapcore"
+)
+
+// TimeLayouts 预定义时间格式名称与布局映射
+var TimeLayouts = map[string]string{
+	"rfc3339":     time.RFC3339,
+	"rfc3339nano": time.RFC3339Nano,
+	"iso8601":     "2006-01-02T15:04:05.000Z0700",
+}
+
+// EpochEncoders 时间戳格式名称与编码器映射
+var EpochEncoders = map[string]zapcore.TimeEncoder{
+	"epoch":        zapcore.EpochTimeEncoder,
+	"epoch_millis": zapcore.EpochMillisTimeEncoder,
+	"epoch_nanos":  zapcore.EpochNanosTimeEncoder,
+}
+
+// TimeZones 支持的时区
+var TimeZones = map[string]*time.Location{
+	"":      time.Local,
+	"local": time.Local,
+	"utc":   time.UTC,
+}
+
+// GetLocation 获取时区，未知时区使用本地时区
+func GetLocation(timeZone string) *time.Location {
+	if loc, ok := TimeZones[strings.ToLower(timeZone)]; ok {
+		return loc
+	}
+	return time.Local
+}
+
+// FormatTime 按时间格式将时间格式化为字符串
+func FormatTime(t time.Time, timeFormat string) string {
+	switch timeFormat {
+	case "epoch":
+		return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', -1, 64)
+	case "epoch_millis":
+		return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Millisecond), 'f', -1, 64)
+	case "epoch_nanos":
+		return strconv.FormatInt(t.UnixNano(), 10)
+	}
+	if layout, ok := TimeLayouts[timeFormat]; ok {
+		return t.Format(layout)
+	}
+	return t.Format(timeFormat)
+}
+
+// GetTimeEncoder 获取时间编码器，时间戳格式输出为数值
+func GetTimeEncoder(timeFormat string, loc *time.Location) zapcore.TimeEncoder {
+	if encoder, ok := EpochEncoders[timeFormat]; ok {
+		return encoder
+	}
+	return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+		enc.AppendString(FormatTime(t.In(loc), timeFormat))
+	}
+}
+
+// GetConsoleTimeEncoder 获取控制台时间编码器
+func GetConsoleTimeEncoder(enableColor bool, timeFormat string, loc *time.Location) zapcore.TimeEncoder {
+	if !enableColor {
+		return GetTimeEncoder(timeFormat, loc)
+	}
+	timeColor := color.New(color.FgWhite, color.Bold)
+	return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+		enc.AppendString(timeColor.Sprint(FormatTime(t.In(loc), timeFormat)))
+	}
+}
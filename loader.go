@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix 环境变量前缀
+const EnvPrefix = "AWESOME_LOG_"
+
+// LoadConfig 从 YAML 或 JSON 文件加载配置
+//
+// 文件中未设置的字段保留默认值，随后使用 AWESOME_LOG_* 环境变量覆盖，
+// 最后校验配置的合法性。
+func LoadConfig(path string) (*Config, error) {
+	config := DefaultConfig()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, config)
+	case ".json":
+		err = json.Unmarshal(data, config)
+	default:
+		return nil, fmt.Errorf("不支持的配置文件类型: %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("解析配置文件 %s 失败: %w", path, err)
+	}
+
+	if err := applyEnv(config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// WithConfigFile 使用配置文件中的配置，加载失败时由 Init 返回错误
+func WithConfigFile(path string) Option {
+	return func(c *Config) {
+		config, err := LoadConfig(path)
+		if err != nil {
+			c.err = err
+			return
+		}
+		*c = *config
+	}
+}
+
+// applyEnv 使用环境变量覆盖配置
+func applyEnv(c *Config) error {
+	var err error
+	lookup := func(key string, apply func(string) error) {
+		if err != nil {
+			return
+		}
+		if value, ok := os.LookupEnv(EnvPrefix + key); ok {
+			if e := apply(value); e != nil {
+				err = fmt.Errorf("环境变量 %s%s 无效: %w", EnvPrefix, key, e)
+			}
+		}
+	}
+	setString := func(dst *string) func(string) error {
+		return func(value string) error {
+			*dst = value
+			return nil
+		}
+	}
+	setInt := func(dst *int) func(string) error {
+		return func(value string) error {
+			n, e := strconv.Atoi(value)
+			if e != nil {
+				return e
+			}
+			*dst = n
+			return nil
+		}
+	}
+	setBool := func(dst *bool) func(string) error {
+		return func(value string) error {
+			b, e := strconv.ParseBool(value)
+			if e != nil {
+				return e
+			}
+			*dst = b
+			return nil
+		}
+	}
+
+	lookup("LEVEL", setString(&c.Level))
+	lookup("FORMAT", setString(&c.Format))
+	lookup("COLOR", setBool(&c.EnableColor))
+	lookup("CALLER", setBool(&c.RecordCaller))
+	lookup("TIME_FORMAT", setString(&c.TimeFormat))
+	lookup("TIME_ZONE", setString(&c.TimeZone))
+	lookup("STACK_LEVEL", setString(&c.StackLevel))
+	lookup("WRITE_TO_FILE", setBool(&c.WriteToFile))
+	lookup("FILE", func(value string) error {
+		// 设置文件路径即启用文件输出
+		c.FileConfig.Filename = value
+		c.WriteToFile = value != ""
+		return nil
+	})
+	lookup("FILE_FORMAT", setString(&c.FileConfig.Format))
+	lookup("FILENAME_PATTERN", func(value string) error {
+		// 设置文件路径模板即启用文件输出
+		c.FileConfig.FilenamePattern = value
+		c.WriteToFile = c.WriteToFile || value != ""
+		return nil
+	})
+	lookup("MAX_SIZE", setInt(&c.FileConfig.MaxSize))
+	lookup("MAX_AGE", setInt(&c.FileConfig.MaxAge))
+	lookup("MAX_BACKUPS", setInt(&c.FileConfig.MaxBackups))
+	lookup("COMPRESS", setBool(&c.FileConfig.Compress))
+	lookup("ROTATION_POLICY", setString(&c.FileConfig.RotationPolicy))
+	lookup("MAX_TOTAL_SIZE", setInt(&c.FileConfig.MaxTotalSize))
+	lookup("MIN_FREE_SPACE", setInt(&c.FileConfig.MinFreeSpace))
+	return err
+}
@@ -1,40 +1,84 @@
 package logger
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/wxlbd/awesome-log/internal"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // Logger 封装的日志结构
 type Logger struct {
+	name string
+	// 日志文件名中使用的名称，与父实例共享文件时为父实例的名称
+	fileName string
+	level    zap.AtomicLevel
+	// 控制台输出目标，为空时输出到标准输出
+	console zapcore.WriteSyncer
+	// 当前生效的日志状态，热加载配置时整体替换
+	state atomic.Pointer[loggerState]
+	// 父日志实例及绑定的字段，子日志实例的状态由父实例派生
+	parent *Logger
+	fields []zap.Field
+	// 统计信息，与通过 With 创建的子实例共享
+	stats *loggerStats
+}
+
+// loggerState 根据配置构建的日志状态
+type loggerState struct {
 	config *Config
 	zap    *zap.Logger
 	sugar  *zap.SugaredLogger
+	// 文件写入器，替换状态后需要释放
+	writer *fileWriter
+	// 按级别过滤的附加输出的写入器
+	outputs []*fileWriter
+	// 派生该状态的父状态
+	base *loggerState
+	// 关闭后停止该状态的后台协程
+	done chan struct{}
 }
 
 var (
-	// 全局日志实例
-	globalLogger *Logger
+	// 全局日志实例，Init 之前使用输出到标准错误的默认实例
+	globalLogger atomic.Pointer[Logger]
 	// 日志实例映射，用于管理多个命名日志实例
 	loggerMap   = make(map[string]*Logger)
 	loggerMutex sync.RWMutex
 )
 
 // NewLogger 创建一个新的命名日志实例
+//
+// 无法识别的配置使用默认值代替，负数按 0 处理，配置错误的采样、限流、去重、
+// 异步写入和附加输出不启用。日志目录不可写、加载配置文件失败或打开日志文件失败时
+// 返回 nil，需要具体原因时使用 NewLoggerE。
 func NewLogger(name string, opts ...Option) *Logger {
+	useDefaults := func(c *Config) {
+		c.useDefaults()
+	}
+	logger, _ := NewLoggerE(name, append(opts[:len(opts):len(opts)], useDefaults)...)
+	return logger
+}
+
+// NewLoggerE 创建一个新的命名日志实例，配置错误时返回具体原因
+func NewLoggerE(name string, opts ...Option) (*Logger, error) {
 	loggerMutex.Lock()
 	defer loggerMutex.Unlock()
 
 	// 双重检查，确保在获取锁的过程中没有其他goroutine创建了logger
 	if logger, exists := loggerMap[name]; exists {
-		return logger
+		return logger, nil
 	}
 
 	// 使用默认配置
@@ -44,81 +88,267 @@ func NewLogger(name string, opts ...Option) *Logger {
 	for _, opt := range opts {
 		opt(config)
 	}
+	if config.err != nil {
+		return nil, config.err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
 
+	logger, err := newLogger(name, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// 保存到映射中
+	loggerMap[name] = logger
+	return logger, nil
+}
+
+// newLogger 根据配置构建日志实例，控制台与文件输出共享同一个动态级别
+func newLogger(name string, config *Config) (*Logger, error) {
 	logger := &Logger{
+		name:     name,
+		fileName: name,
+		level:    zap.NewAtomicLevelAt(internal.GetZapLevel(config.Level)),
+		stats:    new(loggerStats),
+	}
+
+	state, err := logger.buildState(config, false)
+	if err != nil {
+		return nil, err
+	}
+	logger.state.Store(state)
+	return logger, nil
+}
+
+// buildState 根据配置构建日志输出，reconfigure 为 true 时将配置应用到已打开的共享写入器
+func (l *Logger) buildState(config *Config, reconfigure bool) (*loggerState, error) {
+	state := &loggerState{
 		config: config,
+		done:   make(chan struct{}),
 	}
+	level := l.level
 
 	var cores []zapcore.Core
-
-	// 控制台输出
-	consoleEncoderConfig := internal.GetConsoleEncoder(config.EnableColor, config.TimeFormat)
-	consoleEncoder := zapcore.NewConsoleEncoder(consoleEncoderConfig)
+	var guard *internal.DiskGuard
+	loc := internal.GetLocation(config.TimeZone)
+
+	// 控制台输出，非 console 格式（如 json）不使用彩色编码
+	var consoleEncoder zapcore.Encoder
+	if config.Format == "console" {
+		consoleEncoder = zapcore.NewConsoleEncoder(internal.GetConsoleEncoder(config.EnableColor, config.TimeFormat, loc))
+	} else {
+		consoleEncoder = internal.NewEncoder(config.Format, internal.GetFileEncoder(config.TimeFormat, loc))
+	}
 	consoleCore := zapcore.NewCore(
 		consoleEncoder,
-		zapcore.Lock(os.Stdout),
-		internal.GetZapLevel(config.Level),
+		l.consoleOutput(),
+		level,
 	)
 	cores = append(cores, consoleCore)
 
 	// 文件输出
 	if config.WriteToFile {
+		fc := config.FileConfig
 		// 为每个命名logger创建独立的日志文件
-		filename := config.FileConfig.Filename
-		if name != "" {
-			ext := filepath.Ext(filename)
-			filename = filename[:len(filename)-len(ext)] + "." + name + ext
-		}
+		filename := l.logFilename(fc.Filename, fc.FilenamePattern, config.Level, loc)
 
-		// 确保日志目录存在
-		logDir := filepath.Dir(filename)
-		if err := os.MkdirAll(logDir, 0755); err != nil {
-			return nil
+		// 获取日志写入器，相同路径的日志实例共享同一个写入器
+		writer, err := openWriter(filename, fc, loc, reconfigure)
+		if err != nil {
+			return nil, err
 		}
-
-		// 创建日志写入器
-		writer := &lumberjack.Logger{
-			Filename:   filename,
-			MaxSize:    config.FileConfig.MaxSize,
-			MaxBackups: config.FileConfig.MaxBackups,
-			MaxAge:     config.FileConfig.MaxAge,
-			Compress:   config.FileConfig.Compress,
-			LocalTime:  true,
+		state.writer = writer
+		if len(config.ReopenSignals) > 0 {
+			notifyReopen(config.ReopenSignals)
 		}
 
-		fileEncoderConfig := internal.GetFileEncoder(config.TimeFormat)
-		var fileEncoder zapcore.Encoder
-		if config.FileConfig.Format == "json" {
-			fileEncoder = zapcore.NewJSONEncoder(fileEncoderConfig)
-		} else {
-			fileEncoder = zapcore.NewConsoleEncoder(fileEncoderConfig)
+		// 磁盘可用空间不足时只写入 Error 及以上级别的日志
+		if fc.MinFreeSpace > 0 {
+			guard = internal.NewDiskGuard(filepath.Dir(writer.path), uint64(fc.MinFreeSpace)*1024*1024)
+		}
+		cores = append(cores, newFileCore(fc, config.TimeFormat, loc, writer, level, guard))
+
+		// 按级别过滤的附加输出，如将 Warn 及以上级别的日志额外写入 app.error.log
+		for _, output := range fc.Outputs {
+			outputConfig := output.fileConfig(fc)
+			// 文件名包含占位符时作为路径模板
+			var pattern string
+			if strings.Contains(output.Filename, "{") {
+				pattern = output.Filename
+			}
+			filename := l.logFilename(output.Filename, pattern, output.Level, loc)
+
+			outputWriter, err := openWriter(filename, outputConfig, loc, reconfigure)
+			if err != nil {
+				_ = state.release()
+				return nil, err
+			}
+			state.outputs = append(state.outputs, outputWriter)
+
+			minLevel := internal.GetZapLevel(output.Level)
+			enabler := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+				return lvl >= minLevel && level.Enabled(lvl)
+			})
+			cores = append(cores, newFileCore(outputConfig, config.TimeFormat, loc, outputWriter, enabler, guard))
 		}
-
-		fileCore := zapcore.NewCore(
-			fileEncoder,
-			zapcore.AddSync(writer),
-			internal.GetZapLevel(config.Level),
-		)
-		cores = append(cores, fileCore)
 	}
 
 	// 创建Logger
 	core := zapcore.NewTee(cores...)
-	zapLogger := zap.New(core).Named(name)
+	// 先采样再限流，避免被采样丢弃的日志占用令牌
+	if config.RateLimit.Enabled {
+		core = l.rateLimitCore(core, config.RateLimit)
+	}
+	if config.Sampling.Enabled {
+		core = l.sampleCore(core, config.Sampling)
+	}
+	if config.Dedup.Enabled {
+		var deduper *internal.Deduper
+		core, deduper = internal.NewDedupCore(core, config.Dedup.Window, config.Dedup.Fields)
+		go deduper.Run(state.done)
+	}
+	zapLogger := zap.New(core).Named(l.name)
 	if config.RecordCaller {
 		zapLogger = zapLogger.WithOptions(zap.AddCaller(), zap.AddCallerSkip(1))
 	}
 
 	// 设置堆栈跟踪级别
-	stackLevel := internal.GetZapLevel(config.StackLevel)
-	zapLogger = zapLogger.WithOptions(zap.AddStacktrace(stackLevel))
+	zapLogger = zapLogger.WithOptions(zap.AddStacktrace(internal.GetZapLevel(config.StackLevel)))
 
-	logger.zap = zapLogger
-	logger.sugar = zapLogger.Sugar()
+	// 绑定从父实例继承的字段
+	if len(l.fields) > 0 {
+		zapLogger = zapLogger.With(l.fields...)
+	}
 
-	// 保存到映射中
-	loggerMap[name] = logger
-	return logger
+	state.zap = zapLogger
+	state.sugar = zapLogger.Sugar()
+
+	if config.Sampling.Enabled && config.Sampling.SummaryInterval > 0 {
+		go l.reportSampling(zapLogger, config.Sampling.SummaryInterval, state.done)
+	}
+	if guard != nil {
+		go guard.Run(diskCheckInterval, state.done, diskNotice(zapLogger, config.FileConfig.MinFreeSpace))
+	}
+	return state, nil
+}
+
+// logFilename 返回日志实例的文件路径
+//
+// 设置了路径模板时按模板生成，否则在 filename 的扩展名前插入日志实例名称。
+// 两种方式都会将名称中的路径分隔符等特殊字符替换为下划线，避免写到日志目录之外。
+func (l *Logger) logFilename(filename, pattern, level string, loc *time.Location) string {
+	if pattern != "" {
+		return internal.ExpandFilename(pattern, l.fileName, level, loc)
+	}
+	if l.fileName == "" {
+		return filename
+	}
+	ext := filepath.Ext(filename)
+	return filename[:len(filename)-len(ext)] + "." + internal.SanitizeFilename(l.fileName) + ext
+}
+
+// newFileCore 创建写入日志文件的 core
+func newFileCore(fc FileConfig, timeFormat string, loc *time.Location, writer *fileWriter, level zapcore.LevelEnabler, guard *internal.DiskGuard) zapcore.Core {
+	fileEncoder := internal.NewEncoder(fc.Format, internal.GetFileEncoder(timeFormat, loc))
+
+	var fileCore zapcore.Core = zapcore.NewCore(
+		fileEncoder,
+		writer,
+		level,
+	)
+	// 启用缓冲写入时，Error 及以上级别的日志立即刷新到文件
+	if fc.Buffer.Enabled {
+		fileCore = internal.NewFlushCore(fileCore, zapcore.ErrorLevel)
+	}
+	if guard != nil {
+		fileCore = internal.NewDiskGuardCore(fileCore, guard)
+	}
+	return fileCore
+}
+
+// consoleOutput 返回控制台输出目标
+func (l *Logger) consoleOutput() zapcore.WriteSyncer {
+	if l.console != nil {
+		return l.console
+	}
+	return zapcore.Lock(os.Stdout)
+}
+
+// close 同步日志并释放日志状态持有的文件写入器
+func (s *loggerState) close() error {
+	if s.done != nil {
+		close(s.done)
+	}
+	// 控制台输出在部分平台上不支持 Sync，忽略同步错误
+	_ = s.zap.Sync()
+	return s.release()
+}
+
+// release 释放日志状态持有的所有文件写入器
+func (s *loggerState) release() error {
+	var errs []error
+	if s.writer != nil {
+		errs = append(errs, s.writer.release())
+	}
+	for _, output := range s.outputs {
+		errs = append(errs, output.release())
+	}
+	return errors.Join(errs...)
+}
+
+// root 返回派生链最顶层的状态，该状态持有文件写入器
+func (s *loggerState) root() *loggerState {
+	for s.base != nil {
+		s = s.base
+	}
+	return s
+}
+
+// stackLevel 返回堆栈跟踪级别
+func (s *loggerState) stackLevel() zapcore.Level {
+	return internal.GetZapLevel(s.config.StackLevel)
+}
+
+// with 从当前状态派生绑定字段的子状态，子状态共享父状态的输出
+func (s *loggerState) with(fields []zap.Field) *loggerState {
+	zapLogger := s.zap.With(fields...)
+	return &loggerState{
+		config: s.config,
+		zap:    zapLogger,
+		sugar:  zapLogger.Sugar(),
+		base:   s,
+	}
+}
+
+// load 返回当前生效的日志状态，父实例的状态被替换后重新派生子状态
+func (l *Logger) load() *loggerState {
+	state := l.state.Load()
+	if l.parent == nil {
+		return state
+	}
+
+	base := l.parent.load()
+	if state != nil && state.base == base {
+		return state
+	}
+	state = base.with(l.fields)
+	l.state.Store(state)
+	return state
+}
+
+// with 创建绑定字段的子日志实例，子实例不注册到全局映射中
+func (l *Logger) with(fields []zap.Field) *Logger {
+	return &Logger{
+		name:     l.name,
+		fileName: l.fileName,
+		level:    l.level,
+		console:  l.console,
+		parent:   l,
+		fields:   fields,
+		stats:    l.stats,
+	}
 }
 
 // GetLogger 获取指定名称的日志实例
@@ -130,15 +360,14 @@ func GetLogger(name string) *Logger {
 	}
 	loggerMutex.RUnlock()
 
-	// 如果全局logger已经初始化，使用其配置
-	var opts []Option
-	if globalLogger != nil {
-		opts = append(opts, WithFullConfig(globalLogger.config))
-	}
-	return NewLogger(name, opts...)
+	// 使用全局logger的配置
+	return NewLogger(name, WithFullConfig(globalLogger.Load().load().config))
 }
 
 // Init 初始化全局日志实例
+//
+// Init 可以并发或重复调用，重复调用时原有的全局实例就地更新为新配置，
+// 已持有的全局实例句柄继续有效。
 func Init(opts ...Option) error {
 	// 使用默认配置
 	config := DefaultConfig()
@@ -147,212 +376,316 @@ func Init(opts ...Option) error {
 	for _, opt := range opts {
 		opt(config)
 	}
+	if config.err != nil {
+		return fmt.Errorf("初始化日志失败: %w", config.err)
+	}
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("初始化日志失败: %w", err)
+	}
 
-	// 创建全局logger
-	logger := NewLogger("", WithFullConfig(config))
-	if logger == nil {
-		return fmt.Errorf("初始化日志失败")
+	// 创建或更新全局logger
+	loggerMutex.Lock()
+	logger, exists := loggerMap[""]
+	if exists {
+		state, err := logger.buildState(config, true)
+		if err != nil {
+			loggerMutex.Unlock()
+			return fmt.Errorf("初始化日志失败: %w", err)
+		}
+		old := logger.state.Swap(state)
+		logger.level.SetLevel(internal.GetZapLevel(config.Level))
+		_ = old.close()
+	} else {
+		var err error
+		logger, err = newLogger("", config)
+		if err != nil {
+			loggerMutex.Unlock()
+			return fmt.Errorf("初始化日志失败: %w", err)
+		}
+		loggerMap[""] = logger
+	}
+	loggerMutex.Unlock()
+
+	globalLogger.Store(logger)
+
+	// 将 slog 的默认日志实例替换为全局日志实例
+	if config.SlogDefault {
+		slog.SetDefault(logger.Slog())
 	}
-	globalLogger = logger
 	return nil
 }
 
+// ReplaceGlobal 替换全局日志实例，返回恢复原全局实例的函数，便于在测试中使用
+func ReplaceGlobal(logger *Logger) func() {
+	prev := globalLogger.Swap(logger)
+	return func() {
+		globalLogger.Store(prev)
+	}
+}
+
+// newDefaultLogger 创建 Init 之前使用的默认全局实例，输出到标准错误，不注册到全局映射中
+func newDefaultLogger() *Logger {
+	config := DefaultConfig()
+	logger := &Logger{
+		level:   zap.NewAtomicLevelAt(internal.GetZapLevel(config.Level)),
+		console: zapcore.Lock(os.Stderr),
+		stats:   new(loggerStats),
+	}
+
+	// 默认配置不输出到文件，构建不会失败
+	state, _ := logger.buildState(config, false)
+	logger.state.Store(state)
+	return logger
+}
+
+func init() {
+	globalLogger.Store(newDefaultLogger())
+}
+
 // Debug 输出Debug级别日志
 func (l *Logger) Debug(msg string, fields ...zap.Field) {
-	l.zap.Debug(msg, fields...)
+	l.load().zap.Debug(msg, fields...)
 }
 
 // Info 输出Info级别日志
 func (l *Logger) Info(msg string, fields ...zap.Field) {
-	l.zap.Info(msg, fields...)
+	l.load().zap.Info(msg, fields...)
 }
 
 // Warn 输出Warn级别日志
 func (l *Logger) Warn(msg string, fields ...zap.Field) {
-	l.zap.Warn(msg, fields...)
+	l.load().zap.Warn(msg, fields...)
 }
 
 // Error 输出Error级别日志
 func (l *Logger) Error(msg string, fields ...zap.Field) {
-	l.zap.Error(msg, fields...)
+	l.load().zap.Error(msg, fields...)
 }
 
 // Fatal 输出Fatal级别日志
 func (l *Logger) Fatal(msg string, fields ...zap.Field) {
-	l.zap.Fatal(msg, fields...)
+	l.load().zap.Fatal(msg, fields...)
 }
 
 // Debugf 输出Debug级别日志（格式化）
 func (l *Logger) Debugf(template string, args ...interface{}) {
-	l.sugar.Debugf(template, args...)
+	l.load().sugar.Debugf(template, args...)
 }
 
 // Infof 输出Info级别日志（格式化）
 func (l *Logger) Infof(template string, args ...interface{}) {
-	l.sugar.Infof(template, args...)
+	l.load().sugar.Infof(template, args...)
 }
 
 // Warnf 输出Warn级别日志（格式化）
 func (l *Logger) Warnf(template string, args ...interface{}) {
-	l.sugar.Warnf(template, args...)
+	l.load().sugar.Warnf(template, args...)
 }
 
 // Errorf 输出Error级别日志（格式化）
 func (l *Logger) Errorf(template string, args ...interface{}) {
-	l.sugar.Errorf(template, args...)
+	l.load().sugar.Errorf(template, args...)
 }
 
 // Fatalf 输出Fatal级别日志（格式化）
 func (l *Logger) Fatalf(template string, args ...interface{}) {
-	l.sugar.Fatalf(template, args...)
+	l.load().sugar.Fatalf(template, args...)
 }
 
 // Sync 同步缓存的日志
 func (l *Logger) Sync() error {
-	return l.zap.Sync()
+	return l.load().zap.Sync()
+}
+
+// Name 返回日志实例名称
+func (l *Logger) Name() string {
+	return l.name
+}
+
+// Level 返回当前日志级别
+func (l *Logger) Level() string {
+	return l.level.Level().String()
+}
+
+// SetLevel 在运行时调整日志级别，控制台和文件输出同时生效
+func (l *Logger) SetLevel(level string) error {
+	zapLevel, err := internal.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	l.level.SetLevel(zapLevel)
+	return nil
 }
 
 // 以下是全局函数，使用全局logger实例
 
 // Debug 输出Debug级别日志
 func Debug(msg string, fields ...zap.Field) {
-	globalLogger.Debug(msg, fields...)
+	globalLogger.Load().Debug(msg, fields...)
 }
 
 // Info 输出Info级别日志
 func Info(msg string, fields ...zap.Field) {
-	globalLogger.Info(msg, fields...)
+	globalLogger.Load().Info(msg, fields...)
 }
 
 // Warn 输出Warn级别日志
 func Warn(msg string, fields ...zap.Field) {
-	globalLogger.Warn(msg, fields...)
+	globalLogger.Load().Warn(msg, fields...)
 }
 
 // Error 输出Error级别日志
 func Error(msg string, fields ...zap.Field) {
-	globalLogger.Error(msg, fields...)
+	globalLogger.Load().Error(msg, fields...)
 }
 
 // Fatal 输出Fatal级别日志
 func Fatal(msg string, fields ...zap.Field) {
-	globalLogger.Fatal(msg, fields...)
+	globalLogger.Load().Fatal(msg, fields...)
 }
 
 // Debugf 输出Debug级别日志（格式化）
 func Debugf(template string, args ...interface{}) {
-	globalLogger.Debugf(template, args...)
+	globalLogger.Load().Debugf(template, args...)
 }
 
 // Infof 输出Info级别日志（格式化）
 func Infof(template string, args ...interface{}) {
-	globalLogger.Infof(template, args...)
+	globalLogger.Load().Infof(template, args...)
 }
 
 // Warnf 输出Warn级别日志（格式化）
 func Warnf(template string, args ...interface{}) {
-	globalLogger.Warnf(template, args...)
+	globalLogger.Load().Warnf(template, args...)
 }
 
 // Errorf 输出Error级别日志（格式化）
 func Errorf(template string, args ...interface{}) {
-	globalLogger.Errorf(template, args...)
+	globalLogger.Load().Errorf(template, args...)
 }
 
 // Fatalf 输出Fatal级别日志（格式化）
 func Fatalf(template string, args ...interface{}) {
-	globalLogger.Fatalf(template, args...)
+	globalLogger.Load().Fatalf(template, args...)
 }
 
 // Sync 同步缓存的日志
 func Sync() error {
-	return globalLogger.Sync()
+	return globalLogger.Load().Sync()
 }
 
-// WithName 从当前 Logger 实例创建一个新的命名 logger
-func (l *Logger) WithName(name string) *Logger {
-	loggerMutex.Lock()
-	defer loggerMutex.Unlock()
-
-	// 双重检查，确保在获取锁的过程中没有其他goroutine创建了logger
-	if logger, exists := loggerMap[name]; exists {
-		return logger
+// SetGlobalLevel 在运行时调整所有已创建日志实例及全局日志实例的级别
+func SetGlobalLevel(level string) error {
+	zapLevel, err := internal.ParseLevel(level)
+	if err != nil {
+		return err
 	}
 
-	// 使用当前 logger 的配置创建新的 logger
-	config := l.config
-	logger := &Logger{
-		config: config,
+	for _, logger := range loggers() {
+		logger.level.SetLevel(zapLevel)
 	}
+	return nil
+}
 
-	var cores []zapcore.Core
+// loggers 返回所有日志实例
+//
+// Init 之前的默认全局实例不在映射中，以空名称列出，便于统一调整级别。
+func loggers() map[string]*Logger {
+	loggerMutex.RLock()
+	defer loggerMutex.RUnlock()
 
-	// 控制台输出
-	consoleEncoderConfig := internal.GetConsoleEncoder(config.EnableColor, config.TimeFormat)
-	consoleEncoder := zapcore.NewConsoleEncoder(consoleEncoderConfig)
-	consoleCore := zapcore.NewCore(
-		consoleEncoder,
-		zapcore.Lock(os.Stdout),
-		internal.GetZapLevel(config.Level),
-	)
-	cores = append(cores, consoleCore)
+	all := maps.Clone(loggerMap)
+	if _, exists := all[""]; !exists {
+		all[""] = globalLogger.Load()
+	}
+	return all
+}
 
-	// 文件输出
-	if config.WriteToFile {
-		// 为每个命名logger创建独立的日志文件
-		filename := config.FileConfig.Filename
-		if name != "" {
-			ext := filepath.Ext(filename)
-			filename = filename[:len(filename)-len(ext)] + "." + name + ext
-		}
+// With 创建绑定字段的子日志实例
+//
+// 子实例与父实例共享输出、配置、文件写入器和日志级别，不注册到全局映射中。
+func (l *Logger) With(fields ...zap.Field) *Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	return l.with(fields)
+}
 
-		// 确保日志目录存在
-		logDir := filepath.Dir(filename)
-		if err := os.MkdirAll(logDir, 0755); err != nil {
-			return nil
-		}
+// Withw 以键值对的形式创建绑定字段的子日志实例
+func (l *Logger) Withw(keysAndValues ...interface{}) *Logger {
+	return l.With(sweetenFields(keysAndValues)...)
+}
 
-		// 创建日志写入器
-		writer := &lumberjack.Logger{
-			Filename:   filename,
-			MaxSize:    config.FileConfig.MaxSize,
-			MaxBackups: config.FileConfig.MaxBackups,
-			MaxAge:     config.FileConfig.MaxAge,
-			Compress:   config.FileConfig.Compress,
-			LocalTime:  true,
+// sweetenFields 将键值对转换为字段，无法配对的参数记录在 ignored 字段中
+func sweetenFields(keysAndValues []interface{}) []zap.Field {
+	fields := make([]zap.Field, 0, len(keysAndValues)/2)
+	var ignored []interface{}
+	for i := 0; i < len(keysAndValues); i++ {
+		if field, ok := keysAndValues[i].(zap.Field); ok {
+			fields = append(fields, field)
+			continue
 		}
 
-		fileEncoderConfig := internal.GetFileEncoder(config.TimeFormat)
-		var fileEncoder zapcore.Encoder
-		if config.FileConfig.Format == "json" {
-			fileEncoder = zapcore.NewJSONEncoder(fileEncoderConfig)
-		} else {
-			fileEncoder = zapcore.NewConsoleEncoder(fileEncoderConfig)
+		key, ok := keysAndValues[i].(string)
+		if !ok || i == len(keysAndValues)-1 {
+			ignored = append(ignored, keysAndValues[i])
+			continue
 		}
+		fields = append(fields, zap.Any(key, keysAndValues[i+1]))
+		i++
+	}
+	if len(ignored) > 0 {
+		fields = append(fields, zap.Any("ignored", ignored))
+	}
+	return fields
+}
 
-		fileCore := zapcore.NewCore(
-			fileEncoder,
-			zapcore.AddSync(writer),
-			internal.GetZapLevel(config.Level),
-		)
-		cores = append(cores, fileCore)
+// WithName 从当前 Logger 实例创建一个层级命名的 logger
+//
+// 子实例的名称为 "父实例名称.name"，继承父实例的配置、绑定字段和当前日志级别。
+// 默认为子实例创建独立的日志文件，启用 ShareParentFile 时与父实例共享日志文件。
+func (l *Logger) WithName(name string) *Logger {
+	fullName := name
+	if l.name != "" {
+		fullName = l.name + "." + name
 	}
 
-	// 创建Logger
-	core := zapcore.NewTee(cores...)
-	zapLogger := zap.New(core).Named(name)
-	if config.RecordCaller {
-		zapLogger = zapLogger.WithOptions(zap.AddCaller(), zap.AddCallerSkip(1))
+	loggerMutex.Lock()
+	defer loggerMutex.Unlock()
+
+	// 双重检查，确保在获取锁的过程中没有其他goroutine创建了logger
+	if logger, exists := loggerMap[fullName]; exists {
+		return logger
 	}
 
-	// 设置堆栈跟踪级别
-	stackLevel := internal.GetZapLevel(config.StackLevel)
-	zapLogger = zapLogger.WithOptions(zap.AddStacktrace(stackLevel))
+	config := l.load().config
+	logger := &Logger{
+		name:     fullName,
+		fileName: fullName,
+		level:    zap.NewAtomicLevelAt(l.level.Level()),
+		console:  l.console,
+		fields:   l.boundFields(),
+		stats:    new(loggerStats),
+	}
+	if config.FileConfig.ShareParentFile {
+		logger.fileName = l.fileName
+	}
 
-	logger.zap = zapLogger
-	logger.sugar = zapLogger.Sugar()
+	state, err := logger.buildState(config, false)
+	if err != nil {
+		return nil
+	}
+	logger.state.Store(state)
 
 	// 保存到映射中
-	loggerMap[name] = logger
+	loggerMap[fullName] = logger
 	return logger
 }
+
+// boundFields 返回日志实例及其父实例绑定的所有字段
+func (l *Logger) boundFields() []zap.Field {
+	if l.parent == nil {
+		return l.fields
+	}
+	return slices.Concat(l.parent.boundFields(), l.fields)
+}
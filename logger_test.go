@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewLoggerUsesDefaults(t *testing.T) {
+	dir := t.TempDir()
+	l := NewLogger("defaults",
+		WithFileRotation(filepath.Join(dir, "app.log"), -1, -1, -1, false),
+		WithFilenamePattern(filepath.Join(dir, "{unknown}.log")),
+		WithLevelOutput("verbose", filepath.Join(dir, "error.log")),
+		WithAsync(0, time.Second, PolicyBlock),
+		WithSampling(0, 1, 1),
+		WithRateLimit(0, 0),
+		WithDedup(0),
+	)
+	if l == nil {
+		t.Fatal("配置错误时 NewLogger 返回 nil")
+	}
+	defer l.Close()
+
+	config := l.load().config
+	fc := config.FileConfig
+	if fc.MaxSize != 0 || fc.MaxAge != 0 || fc.MaxBackups != 0 {
+		t.Errorf("负数未替换为 0: %+v", fc)
+	}
+	if fc.FilenamePattern != "" || len(fc.Outputs) != 0 {
+		t.Errorf("文件名模板 = %q, 附加输出 = %v", fc.FilenamePattern, fc.Outputs)
+	}
+	if fc.Async.Enabled || config.Sampling.Enabled || config.RateLimit.Enabled || config.Dedup.Enabled {
+		t.Error("配置错误的功能被启用")
+	}
+
+	l.Info("hello")
+	if _, err := os.Stat(filepath.Join(dir, "app.defaults.log")); err != nil {
+		t.Errorf("未写入日志文件: %v", err)
+	}
+}
+
+func TestNewLoggerUnwritableDir(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+
+	// 日志目录不可写时仍返回 nil
+	if l := NewLogger("unwritable", WithFileRotation(filepath.Join(file, "app.log"), 1, 0, 0, false)); l != nil {
+		_ = l.Close()
+		t.Fatal("日志目录不可写时 NewLogger 未返回 nil")
+	}
+}
@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"github.com/wxlbd/awesome-log/internal"
+	"go.uber.org/zap/zapcore"
+)
+
+// RateLimitConfig 日志速率限制配置，每个命名日志实例单独限流
+type RateLimitConfig struct {
+	// 是否启用速率限制
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// 每秒允许输出的日志条数
+	EntriesPerSecond float64 `json:"entriesPerSecond" yaml:"entriesPerSecond"`
+	// 允许突发输出的日志条数
+	Burst int `json:"burst" yaml:"burst"`
+}
+
+// WithRateLimit 按令牌桶限制每个命名日志实例的输出速率
+//
+// 超过限制的日志被丢弃，进入限流状态时输出一条限流提示。
+func WithRateLimit(entriesPerSecond float64, burst int) Option {
+	return func(c *Config) {
+		c.RateLimit = RateLimitConfig{
+			Enabled:          true,
+			EntriesPerSecond: entriesPerSecond,
+			Burst:            burst,
+		}
+	}
+}
+
+// validate 校验速率限制配置
+func (rc RateLimitConfig) validate() []error {
+	var errs []error
+	if rc.EntriesPerSecond <= 0 {
+		errs = append(errs, &ConfigError{Field: "rateLimit.entriesPerSecond", Value: rc.EntriesPerSecond, Err: ErrNonPositiveValue})
+	}
+	if rc.Burst <= 0 {
+		errs = append(errs, &ConfigError{Field: "rateLimit.burst", Value: rc.Burst, Err: ErrNonPositiveValue})
+	}
+	return errs
+}
+
+// rateLimitCore 按速率限制配置包装 core，丢弃的日志计入统计信息
+func (l *Logger) rateLimitCore(core zapcore.Core, rc RateLimitConfig) zapcore.Core {
+	limiter := internal.NewRateLimiter(rc.EntriesPerSecond, rc.Burst)
+	return internal.NewRateLimitCore(core, limiter, func(zapcore.Entry) {
+		l.stats.rateLimited.Add(1)
+	})
+}
@@ -0,0 +1,148 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/wxlbd/awesome-log/internal"
+)
+
+// reloaded 上次重新加载的配置，用于判断配置文件中哪些配置项发生了变化
+var reloaded *Config
+
+// Reload 将新配置应用到所有已创建的日志实例
+//
+// 只应用级别、调用者信息、堆栈跟踪级别、彩色输出和文件轮转配置，
+// 并且只应用相对上次重新加载（首次为全局日志实例的配置）发生变化的配置项，
+// 各日志实例通过选项单独设置的其他配置保持不变。
+// 日志实例本身不会被重建，已持有的 *Logger 继续有效。
+// 配置不合法或任一实例构建失败时不做任何修改，保留原有配置。
+func Reload(config *Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	// 复制配置，避免调用方后续修改影响日志实例
+	cfg := *config
+
+	loggerMutex.Lock()
+	defer loggerMutex.Unlock()
+
+	prev := reloaded
+	if prev == nil {
+		prev = globalLogger.Load().load().config
+	}
+
+	states := make(map[*Logger]*loggerState, len(loggerMap))
+	for name, logger := range loggerMap {
+		loggerConfig := *logger.load().config
+		reloadFields(&loggerConfig, prev, &cfg)
+
+		state, err := logger.buildState(&loggerConfig, true)
+		if err != nil {
+			for _, s := range states {
+				_ = s.close()
+			}
+			return fmt.Errorf("重新加载日志实例 %q 失败: %w", name, err)
+		}
+		states[logger] = state
+	}
+
+	for logger, state := range states {
+		old := logger.state.Swap(state)
+		// 仅在配置中的级别变化时更新，保留运行时通过 SetLevel 调整的级别
+		if state.config.Level != old.config.Level {
+			logger.level.SetLevel(internal.GetZapLevel(state.config.Level))
+		}
+		_ = old.close()
+	}
+	reloaded = &cfg
+	return nil
+}
+
+// reloadFields 将 next 中相对 prev 发生变化的可热加载配置项写入 dst
+func reloadFields(dst, prev, next *Config) {
+	if next.Level != prev.Level {
+		dst.Level = next.Level
+	}
+	if next.RecordCaller != prev.RecordCaller {
+		dst.RecordCaller = next.RecordCaller
+	}
+	if next.StackLevel != prev.StackLevel {
+		dst.StackLevel = next.StackLevel
+	}
+	if next.EnableColor != prev.EnableColor {
+		dst.EnableColor = next.EnableColor
+	}
+
+	fc, prevFC := next.FileConfig, prev.FileConfig
+	if fc.MaxSize != prevFC.MaxSize {
+		dst.FileConfig.MaxSize = fc.MaxSize
+	}
+	if fc.MaxAge != prevFC.MaxAge {
+		dst.FileConfig.MaxAge = fc.MaxAge
+	}
+	if fc.MaxBackups != prevFC.MaxBackups {
+		dst.FileConfig.MaxBackups = fc.MaxBackups
+	}
+	if fc.Compress != prevFC.Compress {
+		dst.FileConfig.Compress = fc.Compress
+	}
+	if fc.RotationPolicy != prevFC.RotationPolicy {
+		dst.FileConfig.RotationPolicy = fc.RotationPolicy
+	}
+}
+
+// WatchConfig 轮询监听配置文件，文件变化时重新加载配置
+//
+// 重新加载失败时通过 onError 回调报告错误，并继续使用原有配置。
+// 返回的 stop 函数用于停止监听。
+func WatchConfig(path string, interval time.Duration, onError func(error)) (stop func(), err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("监听配置文件失败: %w", err)
+	}
+	if onError == nil {
+		onError = func(error) {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		modTime, size := info.ModTime(), info.Size()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				onError(fmt.Errorf("监听配置文件失败: %w", err))
+				continue
+			}
+			if info.ModTime().Equal(modTime) && info.Size() == size {
+				continue
+			}
+			modTime, size = info.ModTime(), info.Size()
+
+			config, err := LoadConfig(path)
+			if err != nil {
+				onError(err)
+				continue
+			}
+			if err := Reload(config); err != nil {
+				onError(err)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}, nil
+}
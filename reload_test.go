@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReloadKeepsPerLoggerConfig(t *testing.T) {
+	dir := t.TempDir()
+	a, err := NewLoggerE("reload-a", WithLevel("debug"), WithFileRotation(filepath.Join(dir, "a.log"), 5, 1, 1, false))
+	if err != nil {
+		t.Fatalf("创建日志实例失败: %v", err)
+	}
+	b, err := NewLoggerE("reload-b", WithLevel("warn"), WithFileRotation(filepath.Join(dir, "b.log"), 7, 1, 1, false), WithFileFormat("console"))
+	if err != nil {
+		t.Fatalf("创建日志实例失败: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = a.Close()
+		_ = b.Close()
+		reloaded = nil
+	})
+
+	// 配置文件与全局配置相同时，各实例的配置保持不变
+	if err := Reload(DefaultConfig()); err != nil {
+		t.Fatalf("重新加载失败: %v", err)
+	}
+	if got := a.Level(); got != "debug" {
+		t.Errorf("a 级别 = %q, 期望 debug", got)
+	}
+	if got := b.Level(); got != "warn" {
+		t.Errorf("b 级别 = %q, 期望 warn", got)
+	}
+
+	// 配置文件中变化的配置项应用到所有实例，其他配置保持不变
+	config := DefaultConfig()
+	config.Level = "error"
+	config.FileConfig.MaxBackups = 3
+	if err := Reload(config); err != nil {
+		t.Fatalf("重新加载失败: %v", err)
+	}
+
+	tests := []struct {
+		logger  *Logger
+		maxSize int
+		format  string
+		file    string
+	}{
+		{logger: a, maxSize: 5, format: "json", file: "a.reload-a.log"},
+		{logger: b, maxSize: 7, format: "console", file: "b.reload-b.log"},
+	}
+	for _, tt := range tests {
+		config := tt.logger.load().config
+		if got := tt.logger.Level(); got != "error" {
+			t.Errorf("%s 级别 = %q, 期望 error", tt.logger.Name(), got)
+		}
+		if !config.WriteToFile {
+			t.Errorf("%s 文件输出被关闭", tt.logger.Name())
+		}
+		if config.FileConfig.MaxSize != tt.maxSize || config.FileConfig.MaxBackups != 3 || config.FileConfig.Format != tt.format {
+			t.Errorf("%s 文件配置 = %+v", tt.logger.Name(), config.FileConfig)
+		}
+
+		tt.logger.Error("reloaded")
+		_ = tt.logger.Sync()
+		if _, err := os.Stat(filepath.Join(dir, tt.file)); err != nil {
+			t.Errorf("%s 未写入日志文件: %v", tt.logger.Name(), err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// 触发重新打开日志文件的信号通道
+	reopenSignals = make(chan os.Signal, 1)
+	reopenOnce    sync.Once
+)
+
+// WithSignalReopen 收到指定信号时重新打开所有日志文件，配合外部 logrotate 使用
+//
+// 外部工具移动日志文件后发送信号（通常为 syscall.SIGHUP），
+// 日志实例会关闭原文件并在原路径重新创建文件。
+func WithSignalReopen(sigs ...os.Signal) Option {
+	return func(c *Config) {
+		c.ReopenSignals = append(c.ReopenSignals, sigs...)
+	}
+}
+
+// notifyReopen 注册重新打开日志文件的信号，首次注册时启动信号处理协程
+func notifyReopen(sigs []os.Signal) {
+	signal.Notify(reopenSignals, sigs...)
+	reopenOnce.Do(func() {
+		go func() {
+			for sig := range reopenSignals {
+				logger := globalLogger.Load().load().zap.WithOptions(zap.WithCaller(false))
+				if err := Reopen(); err != nil {
+					logger.Error(fmt.Sprintf("收到信号 %s，重新打开日志文件失败", sig), zap.Error(err))
+					continue
+				}
+				logger.Info(fmt.Sprintf("收到信号 %s，已重新打开日志文件", sig))
+			}
+		}()
+	})
+}
+
+// Reopen 关闭并在原路径重新打开所有日志文件，不产生轮转文件
+//
+// 异步队列和缓冲区中的日志会先写入原文件。
+func Reopen() error {
+	return eachWriter(func(w *fileWriter) error {
+		return w.reopen()
+	})
+}
+
+// RotateAll 立即轮转所有日志文件
+func RotateAll() error {
+	return eachWriter(func(w *fileWriter) error {
+		return w.rotate()
+	})
+}
+
+// eachWriter 对所有已打开的文件写入器执行操作
+func eachWriter(fn func(w *fileWriter) error) error {
+	writerMutex.Lock()
+	writers := make([]*fileWriter, 0, len(writerMap))
+	for _, w := range writerMap {
+		writers = append(writers, w)
+	}
+	writerMutex.Unlock()
+
+	var errs []error
+	for _, w := range writers {
+		if err := fn(w); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", w.path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,159 @@
+package logger
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wxlbd/awesome-log/internal"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingConfig 日志采样配置，按级别和消息内容对重复日志采样
+type SamplingConfig struct {
+	// 是否启用采样
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// 采样周期
+	Tick time.Duration `json:"tick" yaml:"tick"`
+	// 每个周期内每条消息先输出的条数
+	First int `json:"first" yaml:"first"`
+	// 超过 First 后每隔多少条输出一条，为 0 时全部丢弃
+	Thereafter int `json:"thereafter" yaml:"thereafter"`
+	// 按级别覆盖采样规则，键为日志级别
+	Levels map[string]SamplingRule `json:"levels" yaml:"levels"`
+	// 不采样的最低级别，该级别及以上的日志全部输出，为空时为 error
+	UnsampledLevel string `json:"unsampledLevel" yaml:"unsampledLevel"`
+	// 输出丢弃汇总日志的间隔，为 0 时不输出
+	SummaryInterval time.Duration `json:"summaryInterval" yaml:"summaryInterval"`
+	// 日志被丢弃时的回调
+	Hook func(entry zapcore.Entry) `json:"-" yaml:"-"`
+}
+
+// SamplingRule 单个级别的采样规则
+type SamplingRule struct {
+	// 每个周期内每条消息先输出的条数
+	First int `json:"first" yaml:"first"`
+	// 超过 First 后每隔多少条输出一条，为 0 时全部丢弃
+	Thereafter int `json:"thereafter" yaml:"thereafter"`
+}
+
+// WithSampling 启用日志采样，每个周期 tick 内每条消息先输出 first 条，之后每 thereafter 条输出一条
+//
+// 默认 Error 及以上级别的日志不参与采样。
+func WithSampling(tick time.Duration, first, thereafter int) Option {
+	return func(c *Config) {
+		c.Sampling.Enabled = true
+		c.Sampling.Tick = tick
+		c.Sampling.First = first
+		c.Sampling.Thereafter = thereafter
+	}
+}
+
+// WithSamplingLevel 覆盖指定级别的采样规则
+func WithSamplingLevel(level string, first, thereafter int) Option {
+	return func(c *Config) {
+		if c.Sampling.Levels == nil {
+			c.Sampling.Levels = make(map[string]SamplingRule)
+		}
+		c.Sampling.Levels[level] = SamplingRule{First: first, Thereafter: thereafter}
+	}
+}
+
+// WithSamplingSummary 设置输出丢弃汇总日志的间隔
+func WithSamplingSummary(interval time.Duration) Option {
+	return func(c *Config) {
+		c.Sampling.SummaryInterval = interval
+	}
+}
+
+// WithSamplingHook 设置日志被采样丢弃时的回调
+func WithSamplingHook(hook func(entry zapcore.Entry)) Option {
+	return func(c *Config) {
+		c.Sampling.Hook = hook
+	}
+}
+
+// validate 校验采样配置
+func (sc SamplingConfig) validate() []error {
+	var errs []error
+	if sc.Tick <= 0 {
+		errs = append(errs, &ConfigError{Field: "sampling.tick", Value: sc.Tick, Err: ErrNonPositiveValue})
+	}
+	if sc.First < 0 {
+		errs = append(errs, &ConfigError{Field: "sampling.first", Value: sc.First, Err: ErrNegativeValue})
+	}
+	if sc.Thereafter < 0 {
+		errs = append(errs, &ConfigError{Field: "sampling.thereafter", Value: sc.Thereafter, Err: ErrNegativeValue})
+	}
+	for level, rule := range sc.Levels {
+		if _, err := internal.ParseLevel(level); err != nil {
+			errs = append(errs, &ConfigError{Field: "sampling.levels", Value: level, Err: ErrUnknownLevel})
+		}
+		if rule.First < 0 || rule.Thereafter < 0 {
+			errs = append(errs, &ConfigError{Field: "sampling.levels." + level, Value: rule, Err: ErrNegativeValue})
+		}
+	}
+	if sc.UnsampledLevel != "" {
+		if _, err := internal.ParseLevel(sc.UnsampledLevel); err != nil {
+			errs = append(errs, &ConfigError{Field: "sampling.unsampledLevel", Value: sc.UnsampledLevel, Err: ErrUnknownLevel})
+		}
+	}
+	if sc.SummaryInterval < 0 {
+		errs = append(errs, &ConfigError{Field: "sampling.summaryInterval", Value: sc.SummaryInterval, Err: ErrNegativeValue})
+	}
+	return errs
+}
+
+// rules 返回各级别的采样规则，不采样的级别不在结果中
+func (sc SamplingConfig) rules() map[zapcore.Level]internal.SamplingRule {
+	unsampled := zapcore.ErrorLevel
+	if sc.UnsampledLevel != "" {
+		unsampled = internal.GetZapLevel(sc.UnsampledLevel)
+	}
+
+	rules := make(map[zapcore.Level]internal.SamplingRule)
+	for name, level := range internal.LevelMap {
+		if level >= unsampled {
+			continue
+		}
+		rule := internal.SamplingRule{First: sc.First, Thereafter: sc.Thereafter}
+		if override, ok := sc.Levels[name]; ok {
+			rule = internal.SamplingRule{First: override.First, Thereafter: override.Thereafter}
+		}
+		rules[level] = rule
+	}
+	return rules
+}
+
+// sampleCore 按采样配置包装 core，丢弃的日志计入统计信息
+func (l *Logger) sampleCore(core zapcore.Core, sc SamplingConfig) zapcore.Core {
+	return internal.NewSampler(core, sc.Tick, sc.rules(), func(entry zapcore.Entry, decision zapcore.SamplingDecision) {
+		if decision&zapcore.LogDropped == 0 {
+			return
+		}
+		l.stats.sampled.Add(1)
+		l.stats.sampledPending.Add(1)
+		if sc.Hook != nil {
+			sc.Hook(entry)
+		}
+	})
+}
+
+// reportSampling 定期输出采样丢弃的汇总日志，直到 done 被关闭
+func (l *Logger) reportSampling(zapLogger *zap.Logger, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// 汇总日志不记录调用者信息
+	zapLogger = zapLogger.WithOptions(zap.WithCaller(false))
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if dropped := l.stats.sampledPending.Swap(0); dropped > 0 {
+				zapLogger.Warn(fmt.Sprintf("采样丢弃了 %d 条相似日志", dropped), zap.Uint64("dropped", dropped))
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Close 同步日志并释放文件写入器，同时从全局映射中移除
+//
+// 关闭后的日志实例不再输出任何日志。通过 With 创建的子实例不持有写入器，
+// 关闭时仅同步日志。
+func (l *Logger) Close() error {
+	if l.parent != nil {
+		_ = l.Sync()
+		return nil
+	}
+
+	loggerMutex.Lock()
+	if loggerMap[l.name] == l {
+		delete(loggerMap, l.name)
+	}
+	loggerMutex.Unlock()
+
+	return l.close()
+}
+
+// close 将日志状态替换为空输出，并释放原状态持有的资源
+func (l *Logger) close() error {
+	old := l.state.Swap(&loggerState{
+		config: l.load().config,
+		zap:    zap.NewNop(),
+		sugar:  zap.NewNop().Sugar(),
+	})
+	return old.close()
+}
+
+// Remove 关闭指定名称的日志实例并从全局映射中移除
+func Remove(name string) error {
+	loggerMutex.Lock()
+	logger, exists := loggerMap[name]
+	if exists {
+		delete(loggerMap, name)
+	}
+	loggerMutex.Unlock()
+
+	if !exists {
+		return fmt.Errorf("日志实例不存在: %q", name)
+	}
+	return logger.close()
+}
+
+// Shutdown 同步并关闭所有日志实例，释放所有文件写入器
+//
+// ctx 到期时立即返回 ctx 的错误，剩余的关闭操作在后台继续执行。
+func Shutdown(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		loggerMutex.Lock()
+		loggers := loggerMap
+		loggerMap = make(map[string]*Logger)
+		loggerMutex.Unlock()
+
+		var errs []error
+		for name, logger := range loggers {
+			if err := logger.close(); err != nil {
+				errs = append(errs, fmt.Errorf("关闭日志实例 %q 失败: %w", name, err))
+			}
+		}
+		done <- errors.Join(errs...)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("关闭日志超时: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,171 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// slogHandler 基于 Logger 实现的 slog.Handler
+type slogHandler struct {
+	logger *Logger
+	// 尚未添加属性的分组，添加第一个属性时才创建命名空间，避免输出空分组
+	groups []string
+}
+
+// NewSlogHandler 返回将 slog 日志输出到 Logger 的处理器
+//
+// slog 的分组映射为嵌套的命名空间，属性映射为日志字段，
+// 日志输出复用 Logger 的控制台和文件配置。
+func NewSlogHandler(l *Logger) slog.Handler {
+	return &slogHandler{logger: l}
+}
+
+// Slog 返回基于当前日志实例的 slog.Logger
+func (l *Logger) Slog() *slog.Logger {
+	return slog.New(NewSlogHandler(l))
+}
+
+// Enabled 实现 slog.Handler 接口
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.load().zap.Core().Enabled(slogLevel(level))
+}
+
+// Handle 实现 slog.Handler 接口
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	state := h.logger.load()
+	// record.Time 为零值时编码器不输出时间
+	entry := zapcore.Entry{
+		LoggerName: state.zap.Name(),
+		Time:       record.Time,
+		Level:      slogLevel(record.Level),
+		Message:    record.Message,
+	}
+
+	checked := state.zap.Core().Check(entry, nil)
+	if checked == nil {
+		return nil
+	}
+
+	// 使用 slog 记录的调用位置，而不是处理器内部的调用栈
+	if state.config.RecordCaller && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		checked.Entry.Caller = zapcore.NewEntryCaller(record.PC, frame.File, frame.Line, true)
+	}
+	if checked.Entry.Level >= state.stackLevel() {
+		// 跳过 Handle 和 slog 内部的调用栈
+		checked.Entry.Stack = zap.StackSkip("", 3).String
+	}
+
+	fields := make([]zap.Field, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendSlogAttr(fields, attr)
+		return true
+	})
+	// 上下文字段不属于任何分组，放在分组命名空间之前
+	checked.Write(h.logger.contextFields(ctx, h.openGroups(fields))...)
+	return nil
+}
+
+// openGroups 在属性前创建尚未创建的分组命名空间，没有属性时不创建分组
+func (h *slogHandler) openGroups(fields []zap.Field) []zap.Field {
+	if len(fields) == 0 || len(h.groups) == 0 {
+		return fields
+	}
+	opened := make([]zap.Field, 0, len(h.groups)+len(fields))
+	for _, group := range h.groups {
+		opened = append(opened, zap.Namespace(group))
+	}
+	return append(opened, fields...)
+}
+
+// WithAttrs 实现 slog.Handler 接口
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var fields []zap.Field
+	for _, attr := range attrs {
+		fields = appendSlogAttr(fields, attr)
+	}
+	if len(fields) == 0 {
+		return h
+	}
+	return &slogHandler{logger: h.logger.with(h.openGroups(fields))}
+}
+
+// WithGroup 实现 slog.Handler 接口
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{logger: h.logger, groups: append(h.groups[:len(h.groups):len(h.groups)], name)}
+}
+
+// slogLevel 将 slog 级别映射为 zap 级别
+func slogLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return zapcore.DebugLevel
+	case level < slog.LevelWarn:
+		return zapcore.InfoLevel
+	case level < slog.LevelError:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+// appendSlogAttr 将 slog 属性转换为 zap 字段
+func appendSlogAttr(fields []zap.Field, attr slog.Attr) []zap.Field {
+	value := attr.Value.Resolve()
+	if attr.Key == "" && value.Kind() != slog.KindGroup {
+		return fields
+	}
+
+	switch value.Kind() {
+	case slog.KindBool:
+		return append(fields, zap.Bool(attr.Key, value.Bool()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(attr.Key, value.Int64()))
+	case slog.KindUint64:
+		return append(fields, zap.Uint64(attr.Key, value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(attr.Key, value.Float64()))
+	case slog.KindString:
+		return append(fields, zap.String(attr.Key, value.String()))
+	case slog.KindDuration:
+		return append(fields, zap.Duration(attr.Key, value.Duration()))
+	case slog.KindTime:
+		return append(fields, zap.Time(attr.Key, value.Time()))
+	case slog.KindGroup:
+		group := value.Group()
+		if len(group) == 0 {
+			return fields
+		}
+		// 键为空的分组将属性内联到上一层
+		if attr.Key == "" {
+			for _, a := range group {
+				fields = appendSlogAttr(fields, a)
+			}
+			return fields
+		}
+		return append(fields, zap.Object(attr.Key, slogGroup(group)))
+	default:
+		if err, ok := value.Any().(error); ok {
+			return append(fields, zap.NamedError(attr.Key, err))
+		}
+		return append(fields, zap.Any(attr.Key, value.Any()))
+	}
+}
+
+// slogGroup 将 slog 分组编码为嵌套对象
+type slogGroup []slog.Attr
+
+// MarshalLogObject 实现 zapcore.ObjectMarshaler 接口
+func (g slogGroup) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, field := range appendSlogAttr(nil, slog.Attr{Value: slog.GroupValue(g...)}) {
+		field.AddTo(enc)
+	}
+	return nil
+}
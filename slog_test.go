@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"testing/slogtest"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newBufferLogger 创建以 JSON 格式输出到 buf 的日志实例，不注册到全局映射中
+func newBufferLogger(t *testing.T, buf *bytes.Buffer, opts ...Option) *Logger {
+	t.Helper()
+	config := DefaultConfig()
+	config.Format = "json"
+	config.Level = "debug"
+	config.RecordCaller = false
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	l := &Logger{
+		level:   zap.NewAtomicLevelAt(zapcore.DebugLevel),
+		console: zapcore.AddSync(buf),
+		stats:   new(loggerStats),
+	}
+	state, err := l.buildState(config, false)
+	if err != nil {
+		t.Fatalf("构建日志实例失败: %v", err)
+	}
+	l.state.Store(state)
+	return l
+}
+
+// parseLines 将每行 JSON 日志解析为 map
+func parseLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var entries []map[string]any
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		entry := make(map[string]any)
+		if err := json.Unmarshal(line, &entry); err != nil {
+			t.Fatalf("解析日志失败: %v: %s", err, line)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestSlogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	l := newBufferLogger(t, &buf)
+
+	err := slogtest.TestHandler(NewSlogHandler(l), func() []map[string]any {
+		return parseLines(t, &buf)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// traceKey 测试用的上下文键
+type traceKey struct{}
+
+func TestSlogHandlerContextFields(t *testing.T) {
+	var buf bytes.Buffer
+	extractor := func(ctx context.Context) []zap.Field {
+		if id, ok := ctx.Value(traceKey{}).(string); ok {
+			return []zap.Field{zap.String("trace_id", id)}
+		}
+		return nil
+	}
+	l := newBufferLogger(t, &buf, WithContextExtractor(extractor))
+
+	ctx := WithContext(context.WithValue(context.Background(), traceKey{}, "abc"), zap.String("request_id", "r1"))
+	l.Slog().WithGroup("g").InfoContext(ctx, "hello", "k", "v")
+
+	entries := parseLines(t, &buf)
+	if len(entries) != 1 {
+		t.Fatalf("日志条数 = %d, 期望 1", len(entries))
+	}
+	entry := entries[0]
+	if entry["request_id"] != "r1" || entry["trace_id"] != "abc" {
+		t.Errorf("缺少上下文字段: %v", entry)
+	}
+	if group, ok := entry["g"].(map[string]any); !ok || group["k"] != "v" {
+		t.Errorf("分组字段 = %v", entry["g"])
+	}
+}
@@ -0,0 +1,39 @@
+package logger
+
+import "sync/atomic"
+
+// Stats 日志实例的统计信息
+type Stats struct {
+	// 异步写入队列满时丢弃的日志条数，共享同一文件的日志实例共享该计数
+	AsyncDropped uint64 `json:"asyncDropped"`
+	// 采样丢弃的日志条数
+	SampledDropped uint64 `json:"sampledDropped"`
+	// 超过速率限制被丢弃的日志条数
+	RateLimited uint64 `json:"rateLimited"`
+}
+
+// loggerStats 日志实例的统计计数，热加载配置后保留
+type loggerStats struct {
+	// 采样丢弃的日志条数
+	sampled atomic.Uint64
+	// 上次输出汇总日志后采样丢弃的日志条数
+	sampledPending atomic.Uint64
+	// 超过速率限制被丢弃的日志条数
+	rateLimited atomic.Uint64
+}
+
+// Stats 返回日志实例的统计信息
+func (l *Logger) Stats() Stats {
+	stats := Stats{
+		SampledDropped: l.stats.sampled.Load(),
+		RateLimited:    l.stats.rateLimited.Load(),
+	}
+	root := l.load().root()
+	if root.writer != nil {
+		stats.AsyncDropped = root.writer.dropped()
+	}
+	for _, output := range root.outputs {
+		stats.AsyncDropped += output.dropped()
+	}
+	return stats
+}
@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"context"
+	"encoding/binary"
+	"strconv"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// TraceStyle 链路追踪字段的命名和编码方式，字段名为空时不输出该字段
+type TraceStyle struct {
+	// trace_id 字段名
+	TraceIDKey string
+	// span_id 字段名
+	SpanIDKey string
+	// trace_flags 字段名
+	TraceFlagsKey string
+	// 是否以十进制输出 64 位 ID（trace_id 取低 64 位），Datadog 使用该格式
+	DecimalIDs bool
+}
+
+var (
+	// W3CTraceStyle W3C Trace Context 风格，ID 以十六进制输出
+	W3CTraceStyle = TraceStyle{
+		TraceIDKey:    "trace_id",
+		SpanIDKey:     "span_id",
+		TraceFlagsKey: "trace_flags",
+	}
+	// DatadogTraceStyle Datadog 风格，ID 以十进制输出
+	DatadogTraceStyle = TraceStyle{
+		TraceIDKey: "dd.trace_id",
+		SpanIDKey:  "dd.span_id",
+		DecimalIDs: true,
+	}
+)
+
+// TraceExtractor 返回从 OpenTelemetry span 中提取链路追踪字段的上下文提取器
+//
+// 上下文中没有有效的 span 时不输出任何字段。
+func TraceExtractor(style TraceStyle) ContextExtractor {
+	return func(ctx context.Context) []zap.Field {
+		spanContext := trace.SpanContextFromContext(ctx)
+		if !spanContext.IsValid() {
+			return nil
+		}
+
+		fields := make([]zap.Field, 0, 3)
+		if style.TraceIDKey != "" {
+			traceID := spanContext.TraceID()
+			if style.DecimalIDs {
+				fields = append(fields, zap.String(style.TraceIDKey, strconv.FormatUint(binary.BigEndian.Uint64(traceID[8:]), 10)))
+			} else {
+				fields = append(fields, zap.String(style.TraceIDKey, traceID.String()))
+			}
+		}
+		if style.SpanIDKey != "" {
+			spanID := spanContext.SpanID()
+			if style.DecimalIDs {
+				fields = append(fields, zap.String(style.SpanIDKey, strconv.FormatUint(binary.BigEndian.Uint64(spanID[:]), 10)))
+			} else {
+				fields = append(fields, zap.String(style.SpanIDKey, spanID.String()))
+			}
+		}
+		if style.TraceFlagsKey != "" {
+			fields = append(fields, zap.String(style.TraceFlagsKey, spanContext.TraceFlags().String()))
+		}
+		return fields
+	}
+}
+
+// WithTraceContext 注册 OpenTelemetry 链路追踪字段提取器
+func WithTraceContext(style TraceStyle) Option {
+	return WithContextExtractor(TraceExtractor(style))
+}
@@ -0,0 +1,431 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wxlbd/awesome-log/internal"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+var (
+	// 文件写入器映射，按绝对路径共享写入器
+	writerMap   = make(map[string]*fileWriter)
+	writerMutex sync.Mutex
+)
+
+// fileWriter 按文件路径共享的日志写入器
+//
+// 相同路径的日志实例通过引用计数共享同一个写入器和互斥锁，
+// 保证文件只被轮转一次。
+type fileWriter struct {
+	mu   sync.Mutex
+	path string
+	refs int
+	// 最后一个引用释放后为 true，之后的写入直接丢弃，避免重新打开文件
+	closed bool
+	// 轮转写入器及其配置
+	logger   rotateWriter
+	rotation rotationConfig
+	// 总大小限制配置及上次检查后写入的字节数
+	retention retentionConfig
+	written   int64
+	trimming  atomic.Bool
+	// 异步写入器，未启用异步写入时为空
+	async       atomic.Pointer[internal.AsyncWriter]
+	asyncConfig AsyncConfig
+	// 缓冲写入器，未启用缓冲写入时为空
+	buffer       atomic.Pointer[zapcore.BufferedWriteSyncer]
+	bufferConfig BufferConfig
+}
+
+// rotateWriter 支持轮转的日志文件写入器
+type rotateWriter interface {
+	io.WriteCloser
+	// Rotate 立即轮转当前文件
+	Rotate() error
+}
+
+// rotationConfig 写入器的轮转配置，用于判断配置是否变化
+type rotationConfig struct {
+	policy     string
+	maxSize    int
+	maxBackups int
+	maxAge     int
+	compress   bool
+	loc        *time.Location
+}
+
+// retentionConfig 写入器的总大小限制配置
+type retentionConfig struct {
+	// 最大总大小（字节），为 0 时不限制
+	maxTotalSize int64
+	// 共同计算总大小的文件名前缀，为空时只统计当前日志文件及其轮转文件
+	sharedPrefix string
+}
+
+// openWriter 获取指定路径的写入器，路径已打开时增加引用计数
+//
+// 共享写入器的轮转、总大小限制、缓冲和异步写入配置以第一个打开者为准，
+// 配置不同时返回 ErrWriterConflict。reconfigure 为 true 时改为应用新的配置，
+// 仅用于 Reload 和重复调用 Init 时整体替换配置。
+func openWriter(filename string, fc FileConfig, loc *time.Location, reconfigure bool) (*fileWriter, error) {
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, fmt.Errorf("解析日志文件路径失败: %w", err)
+	}
+
+	writerMutex.Lock()
+	defer writerMutex.Unlock()
+
+	if w, exists := writerMap[path]; exists {
+		if reconfigure {
+			w.configure(fc, loc)
+		} else if !w.matches(fc, loc) {
+			return nil, fmt.Errorf("%w: %s", ErrWriterConflict, path)
+		}
+		w.refs++
+		return w, nil
+	}
+
+	// 确保日志目录存在
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("创建日志目录失败: %w", err)
+	}
+
+	w := &fileWriter{
+		path: path,
+		refs: 1,
+	}
+	w.configure(fc, loc)
+	writerMap[path] = w
+	return w, nil
+}
+
+// configure 应用轮转和异步写入配置
+//
+// 轮转写入器的后台清理协程会读取轮转配置，配置变化时替换为新的实例而不是直接修改字段。
+func (w *fileWriter) configure(fc FileConfig, loc *time.Location) {
+	w.configureRotation(fc, loc)
+	w.configureRetention(fc)
+	w.configureBuffer(fc.Buffer)
+	w.configureAsync(fc.Async)
+}
+
+// matches 判断写入器当前的配置是否与给定配置相同，调用方需持有 writerMutex
+func (w *fileWriter) matches(fc FileConfig, loc *time.Location) bool {
+	if w.asyncConfig != fc.Async || w.bufferConfig != fc.Buffer {
+		return false
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rotation == newRotationConfig(fc, loc) && w.retention == newRetentionConfig(fc)
+}
+
+// newRotationConfig 根据文件配置生成轮转配置
+func newRotationConfig(fc FileConfig, loc *time.Location) rotationConfig {
+	return rotationConfig{
+		policy:     fc.RotationPolicy,
+		maxSize:    fc.MaxSize,
+		maxBackups: fc.MaxBackups,
+		maxAge:     fc.MaxAge,
+		compress:   fc.Compress,
+		loc:        loc,
+	}
+}
+
+// configureRotation 应用轮转配置
+func (w *fileWriter) configureRotation(fc FileConfig, loc *time.Location) {
+	rc := newRotationConfig(fc, loc)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.logger != nil {
+		if w.rotation == rc {
+			return
+		}
+		_ = w.logger.Close()
+	}
+	w.rotation = rc
+	w.logger = rc.newWriter(w.path)
+}
+
+// newWriter 按轮转策略创建写入器
+func (rc rotationConfig) newWriter(path string) rotateWriter {
+	switch rc.policy {
+	case RotationDaily, RotationHourly, RotationSizeAndTime:
+		maxSize := 0
+		if rc.policy == RotationSizeAndTime {
+			maxSize = rc.maxSize
+		}
+		return &internal.TimeRotator{
+			Filename:   path,
+			Period:     rc.policy,
+			MaxSize:    maxSize,
+			MaxAge:     rc.maxAge,
+			MaxBackups: rc.maxBackups,
+			Compress:   rc.compress,
+			Location:   rc.loc,
+		}
+	default:
+		return &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    rc.maxSize,
+			MaxBackups: rc.maxBackups,
+			MaxAge:     rc.maxAge,
+			Compress:   rc.compress,
+			LocalTime:  rc.loc != time.UTC,
+		}
+	}
+}
+
+// newRetentionConfig 根据文件配置生成总大小限制配置
+func newRetentionConfig(fc FileConfig) retentionConfig {
+	rc := retentionConfig{maxTotalSize: int64(fc.MaxTotalSize) * 1024 * 1024}
+	if fc.ShareTotalSize {
+		base := filepath.Base(fc.Filename)
+		rc.sharedPrefix = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	return rc
+}
+
+// configureRetention 应用总大小限制配置，并立即检查一次总大小
+func (w *fileWriter) configureRetention(fc FileConfig) {
+	rc := newRetentionConfig(fc)
+
+	w.mu.Lock()
+	w.retention = rc
+	w.written = 0
+	w.mu.Unlock()
+
+	if rc.maxTotalSize > 0 {
+		w.trim()
+	}
+}
+
+// configureAsync 应用异步写入配置，配置变化时先写入原队列中的日志
+func (w *fileWriter) configureAsync(ac AsyncConfig) {
+	if w.asyncConfig == ac {
+		return
+	}
+	w.asyncConfig = ac
+
+	var async *internal.AsyncWriter
+	if ac.Enabled {
+		async = internal.NewAsyncWriter(writerFunc(w.write), ac.BufferSize, ac.FlushInterval, ac.Policy)
+	}
+	if old := w.async.Swap(async); old != nil {
+		_ = old.Close()
+	}
+}
+
+// configureBuffer 应用缓冲写入配置，配置变化时先刷新原缓冲区
+func (w *fileWriter) configureBuffer(bc BufferConfig) {
+	if w.bufferConfig == bc {
+		return
+	}
+	w.bufferConfig = bc
+
+	var buffer *zapcore.BufferedWriteSyncer
+	if bc.Enabled {
+		buffer = &zapcore.BufferedWriteSyncer{
+			WS:            zapcore.AddSync(writerFunc(w.writeFile)),
+			Size:          bc.Size,
+			FlushInterval: bc.FlushInterval,
+		}
+	}
+	if old := w.buffer.Swap(buffer); old != nil {
+		_ = old.Stop()
+	}
+}
+
+// Write 实现 io.Writer 接口，启用异步写入时写入队列
+func (w *fileWriter) Write(p []byte) (int, error) {
+	if async := w.async.Load(); async != nil {
+		return async.Write(p)
+	}
+	return w.write(p)
+}
+
+// write 同步写入日志，启用缓冲写入时写入缓冲区
+func (w *fileWriter) write(p []byte) (int, error) {
+	if buffer := w.buffer.Load(); buffer != nil {
+		return buffer.Write(p)
+	}
+	return w.writeFile(p)
+}
+
+// writeFile 写入日志文件
+func (w *fileWriter) writeFile(p []byte) (int, error) {
+	w.mu.Lock()
+	// 替换前加载的日志状态可能在写入器释放后继续写入
+	if w.closed {
+		w.mu.Unlock()
+		return len(p), nil
+	}
+	n, err := w.logger.Write(p)
+	// 每写入总大小限制的 1/16 检查一次总大小
+	w.written += int64(n)
+	check := w.retention.maxTotalSize > 0 && w.written >= w.retention.maxTotalSize/16
+	if check {
+		w.written = 0
+	}
+	w.mu.Unlock()
+
+	if check {
+		w.trim()
+	}
+	return n, err
+}
+
+// trim 在后台删除最旧的轮转文件，直到总大小不超过限制
+func (w *fileWriter) trim() {
+	if !w.trimming.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer w.trimming.Store(false)
+
+		w.mu.Lock()
+		rc := w.retention
+		w.mu.Unlock()
+
+		dir := filepath.Dir(w.path)
+		ext := filepath.Ext(w.path)
+		// 只统计当前日志文件及其轮转文件，共享时再加上同前缀命名日志实例的文件，
+		// 如 app.log, app-<时间>.log, app.<名称>.log, app.<名称>-<时间>.log
+		base := strings.TrimSuffix(filepath.Base(w.path), ext)
+		match := func(name string) bool {
+			fileBase, ok := internal.LogFileBase(name, ext)
+			if !ok {
+				return false
+			}
+			if rc.sharedPrefix != "" {
+				return fileBase == rc.sharedPrefix || (strings.HasPrefix(fileBase, rc.sharedPrefix+".") && len(fileBase) > len(rc.sharedPrefix)+1)
+			}
+			return fileBase == base
+		}
+
+		// 同目录下所有写入器正在写入的文件都不能删除
+		active := make(map[string]bool)
+		writerMutex.Lock()
+		for _, other := range writerMap {
+			if filepath.Dir(other.path) == dir {
+				active[other.active()] = true
+			}
+		}
+		writerMutex.Unlock()
+		active[w.active()] = true
+
+		_ = internal.TrimTotalSize(dir, match, active, rc.maxTotalSize)
+	}()
+}
+
+// active 返回当前正在写入的文件路径
+func (w *fileWriter) active() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if rotator, ok := w.logger.(*internal.TimeRotator); ok {
+		if name := rotator.Active(); name != "" {
+			return name
+		}
+	}
+	return w.path
+}
+
+// Sync 实现 zapcore.WriteSyncer 接口，等待异步队列和缓冲区中的日志写入文件
+func (w *fileWriter) Sync() error {
+	if async := w.async.Load(); async != nil {
+		if err := async.Sync(); err != nil {
+			return err
+		}
+	}
+	if buffer := w.buffer.Load(); buffer != nil {
+		return buffer.Sync()
+	}
+	return nil
+}
+
+// reopen 关闭当前文件，下次写入时在原路径重新打开
+func (w *fileWriter) reopen() error {
+	_ = w.Sync()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	return w.logger.Close()
+}
+
+// rotate 立即轮转当前文件
+func (w *fileWriter) rotate() error {
+	_ = w.Sync()
+
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	err := w.logger.Rotate()
+	trim := w.retention.maxTotalSize > 0
+	w.mu.Unlock()
+
+	if trim {
+		w.trim()
+	}
+	return err
+}
+
+// dropped 返回异步写入队列满时丢弃的日志条数
+func (w *fileWriter) dropped() uint64 {
+	if async := w.async.Load(); async != nil {
+		return async.Dropped()
+	}
+	return 0
+}
+
+// release 释放写入器引用，最后一个引用释放时关闭文件
+//
+// 关闭后的写入器不再写入文件，之后打开相同路径时创建新的写入器。
+func (w *fileWriter) release() error {
+	writerMutex.Lock()
+	defer writerMutex.Unlock()
+
+	w.refs--
+	if w.refs > 0 {
+		return nil
+	}
+	delete(writerMap, w.path)
+
+	// 先写入异步队列中剩余的日志，再关闭文件
+	if async := w.async.Swap(nil); async != nil {
+		_ = async.Close()
+	}
+	if buffer := w.buffer.Swap(nil); buffer != nil {
+		_ = buffer.Stop()
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	return w.logger.Close()
+}
+
+// writerFunc 将函数适配为 io.Writer
+type writerFunc func(p []byte) (int, error)
+
+// Write 实现 io.Writer 接口
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriterClosedAfterRelease(t *testing.T) {
+	dir := t.TempDir()
+	opts := []Option{WithFileRotation(filepath.Join(dir, "app.log"), 1, 0, 0, false)}
+	l, err := NewLoggerE("wac", opts...)
+	if err != nil {
+		t.Fatalf("创建日志实例失败: %v", err)
+	}
+	path := filepath.Join(dir, "app.wac.log")
+
+	l.Info("before")
+	state := l.load()
+	if err := l.Close(); err != nil {
+		t.Fatalf("关闭失败: %v", err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("删除日志文件失败: %v", err)
+	}
+
+	// 关闭前加载的状态继续写入时不能重新创建文件
+	state.zap.Info("after close")
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("关闭后写入重新创建了日志文件: %v", err)
+	}
+
+	// 再次打开相同路径时创建新的写入器
+	l2, err := NewLoggerE("wac", opts...)
+	if err != nil {
+		t.Fatalf("创建日志实例失败: %v", err)
+	}
+	defer l2.Close()
+	if l2.load().writer == state.writer {
+		t.Fatal("重新打开时复用了已关闭的写入器")
+	}
+	l2.Info("reopened")
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("重新打开后未写入日志文件: %v", err)
+	}
+}
+
+func TestLogFilenameSanitizesName(t *testing.T) {
+	dir := t.TempDir()
+	l, err := NewLoggerE("../../escape", WithFileRotation(filepath.Join(dir, "sub", "app.log"), 1, 0, 0, false))
+	if err != nil {
+		t.Fatalf("创建日志实例失败: %v", err)
+	}
+	defer l.Close()
+
+	want := filepath.Join(dir, "sub", "app._.._escape.log")
+	if got := l.load().writer.path; got != want {
+		t.Errorf("日志文件 = %q, 期望 %q", got, want)
+	}
+}
+
+func TestSharedWriter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "shared.log")
+	async := WithAsync(16, time.Hour, PolicyBlock)
+
+	a, err := NewLoggerE("shared-a", WithFilenamePattern(path), async)
+	if err != nil {
+		t.Fatalf("创建日志实例失败: %v", err)
+	}
+
+	// 写入配置不同时不能替换第一个打开者的配置
+	if _, err := NewLoggerE("shared-b", WithFilenamePattern(path)); !errors.Is(err, ErrWriterConflict) {
+		t.Fatalf("错误 = %v, 期望 ErrWriterConflict", err)
+	}
+	w := a.load().writer
+	if w.async.Load() == nil {
+		t.Fatal("共享写入器的异步写入被关闭")
+	}
+
+	b, err := NewLoggerE("shared-b", WithFilenamePattern(path), async)
+	if err != nil {
+		t.Fatalf("创建日志实例失败: %v", err)
+	}
+	if b.load().writer != w || w.refs != 2 {
+		t.Fatalf("未共享写入器: refs = %d", w.refs)
+	}
+
+	// 共享路径只轮转一次
+	a.Info("a")
+	b.Info("b")
+	_ = a.Sync()
+	if err := RotateAll(); err != nil {
+		t.Fatalf("轮转失败: %v", err)
+	}
+	if got := listDir(t, dir); len(got) != 2 {
+		t.Fatalf("文件 = %v, 期望当前文件和一个轮转文件", got)
+	}
+
+	// 最后一个引用释放后才关闭写入器
+	if err := a.Close(); err != nil {
+		t.Fatalf("关闭失败: %v", err)
+	}
+	if writerMap[w.path] != w || w.refs != 1 {
+		t.Fatalf("关闭一个实例后写入器被释放: refs = %d", w.refs)
+	}
+	b.Info("after a closed")
+	if err := b.Close(); err != nil {
+		t.Fatalf("关闭失败: %v", err)
+	}
+	if _, exists := writerMap[w.path]; exists {
+		t.Fatal("关闭所有实例后写入器仍在映射中")
+	}
+}
+
+// listDir 返回目录中的文件名
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("读取目录失败: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}